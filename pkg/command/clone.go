@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/filipenos/projects/pkg/config"
+	"github.com/filipenos/projects/pkg/editor"
+	"github.com/filipenos/projects/pkg/git"
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "clone <url>",
+		Short: "Clone a git repository and register it as project",
+		Long: `Clone a git repository and register it as project.
+
+The destination follows the clone_layout config (default ` + config.DefaultCloneLayout + `),
+where Host, Owner and Repo come from the url. The owner becomes the project group
+and the host a tag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: clone,
+	}
+	cmd.Flags().String("name", "", "Project name (default is the repository name)")
+	cmd.Flags().String("dir", "", "Clone into this directory instead of the configured layout")
+	cmd.Flags().Bool("open", false, "Open the project in the default editor after cloning")
+	rootCmd.AddCommand(cmd)
+}
+
+func clone(cmdParam *cobra.Command, params []string) error {
+	remote, err := git.ParseRemote(params[0])
+	if err != nil {
+		return err
+	}
+
+	dir := SafeStringFlag(cmdParam, "dir")
+	if dir == "" {
+		layout := cfg.CloneLayout
+		if layout == "" {
+			layout = config.DefaultCloneLayout
+		}
+		if dir, err = cloneTarget(layout, remote); err != nil {
+			return err
+		}
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return err
+	}
+
+	p := &project.Project{
+		Name:     remote.Repo,
+		RootPath: dir,
+		Group:    remote.Owner,
+		Tags:     []string{remote.Host},
+		Enabled:  true,
+		SCM:      params[0],
+	}
+	if name := SafeStringFlag(cmdParam, "name"); name != "" {
+		p.Name = name
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	if err := projects.CheckConflicts(p, -1); err != nil {
+		return fmt.Errorf("%w, use --name to choose another name", err)
+	}
+
+	switch {
+	case git.IsRepo(dir) && git.RemoteURL(dir) == params[0]:
+		log.Infof("'%s' already cloned, registering it", dir)
+	case path.Exist(dir):
+		return fmt.Errorf("destination '%s' already exists", dir)
+	default:
+		if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+			return err
+		}
+		log.Infof("cloning %s into %s", params[0], dir)
+		if err := git.Clone(context.Background(), params[0], dir, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	projects = append(projects, *p)
+	if err := projects.Save(cfg); err != nil {
+		return err
+	}
+	log.Infof("Add project: '%s' path: '%s'", p.Name, p.RootPath)
+
+	if !SafeBoolFlag(cmdParam, "open") {
+		return nil
+	}
+	p.ProjectType = project.ProjectTypeLocal
+	p.ValidPath = true
+	return editorService.OpenProject(cfg.Editor, p, editor.WindowTypeNew)
+}
+
+// cloneTarget expands the clone layout template for remote.
+func cloneTarget(layout string, remote git.Remote) (string, error) {
+	tmpl, err := template.New("layout").Parse(layout)
+	if err != nil {
+		return "", fmt.Errorf("invalid clone layout: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, remote); err != nil {
+		return "", fmt.Errorf("invalid clone layout: %w", err)
+	}
+
+	dir := b.String()
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		dir = filepath.Join(os.Getenv("HOME"), dir[1:])
+	}
+	return filepath.Clean(dir), nil
+}
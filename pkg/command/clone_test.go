@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/config"
+	"github.com/filipenos/projects/pkg/git"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestCloneTarget(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+	remote := git.Remote{Host: "github.com", Owner: "filipenos", Repo: "projects"}
+
+	got, err := cloneTarget(config.DefaultCloneLayout, remote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "/home/user/src/github.com/filipenos/projects"; got != expected {
+		t.Fatalf("cloneTarget returned %s, expected %s", got, expected)
+	}
+
+	got, _ = cloneTarget("/code/{{.Repo}}", git.Remote{Host: "local", Repo: "svc"})
+	if got != "/code/svc" {
+		t.Fatalf("unexpected custom layout result: %s", got)
+	}
+
+	if _, err := cloneTarget("/code/{{.Missing}}", remote); err == nil {
+		t.Fatalf("expected error for unknown layout field")
+	}
+}
+
+func TestCloneRegistersProject(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	base := t.TempDir()
+	ctx := context.Background()
+	seed := filepath.Join(base, "seed")
+	bare := filepath.Join(base, "team", "service.git")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", seed},
+		{"-C", seed, "commit", "-q", "--allow-empty", "-m", "initial"},
+		{"clone", "-q", "--bare", seed, bare},
+	} {
+		if _, err := git.Run(ctx, base, args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	original := cfg
+	defer func() { cfg = original }()
+	cfg.ProjectLocation = filepath.Join(base, "projects.json")
+	cfg.CloneLayout = filepath.Join(base, "src", "{{.Host}}", "{{.Owner}}", "{{.Repo}}")
+
+	cmd, _, err := rootCmd.Find([]string{"clone"})
+	if err != nil {
+		t.Fatalf("clone command not found: %v", err)
+	}
+	url := "file://" + bare
+	if err := clone(cmd, []string{url}); err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected one project, got %d", len(projects))
+	}
+	p := projects[0]
+	dir := filepath.Join(base, "src", "local", "team", "service")
+	if p.Name != "service" || p.Group != "team" || p.RootPath != dir || p.SCM != url {
+		t.Fatalf("unexpected project: %+v", p)
+	}
+	if got := git.RemoteURL(dir); got != url {
+		t.Fatalf("clone has remote %q, expected %q", got, url)
+	}
+
+	if err := clone(cmd, []string{url}); err == nil {
+		t.Fatalf("expected conflict when cloning the same project twice")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/filipenos/projects/pkg/editor"
+	"github.com/filipenos/projects/pkg/log"
 	"github.com/filipenos/projects/pkg/path"
 	"github.com/filipenos/projects/pkg/project"
 	"github.com/spf13/cobra"
@@ -17,24 +18,26 @@ func init() {
 		Aliases: editorService.Aliases(),
 		Short:   fmt.Sprintf("Edit your project using the editor (%s as default)", cfg.Editor),
 		RunE:    code,
+
+		ValidArgsFunction: completeProjectNames,
 	}
 	codeCmd.Flags().StringP("window", "w", "new", "Window type (new|reuse|add)")
+	codeCmd.RegisterFlagCompletionFunc("window", completeFixedValues("new", "reuse", "add"))
+	codeCmd.Flags().StringP("editor", "e", "", "Editor to use instead of the one in the command name")
+	codeCmd.RegisterFlagCompletionFunc("editor", completeFixedValues(editorService.Aliases()...))
 	rootCmd.AddCommand(codeCmd)
 }
 
 func code(cmdParam *cobra.Command, params []string) error {
-	if len(params) == 0 {
-		return fmt.Errorf("project name is required")
-	}
-
 	projects, err := project.Load(cfg)
 	if err != nil {
 		return err
 	}
 
-	p, _ := projects.Find(path.SafeName(params...))
-	if p == nil {
-		return fmt.Errorf("project not found")
+	name, pwd := path.SafeName(params...)
+	p, _, err := findProject(projects, name, pwd)
+	if err != nil {
+		return err
 	}
 
 	if err := p.Validate(); err != nil {
@@ -53,5 +56,31 @@ func code(cmdParam *cobra.Command, params []string) error {
 		window = editor.WindowTypeNew
 	}
 
-	return editorService.OpenProject(cmdParam.CalledAs(), p, window)
+	editorName := cmdParam.CalledAs()
+	if name := SafeStringFlag(cmdParam, "editor"); name != "" {
+		editorName = name
+	}
+
+	e, ok := editorService.Editor(editorName)
+	if ok && e.LocalOnly {
+		p = localWSLProject(p)
+	}
+	// editors that only take paths open SSH projects through an sshfs mount
+	if ok && e.LocalOnly && p.ProjectType == project.ProjectTypeSSH {
+		m, created, err := mountSSHProject(p, e.Terminal)
+		if err != nil {
+			return err
+		}
+		if created && e.Terminal {
+			// terminal editors block, so the mount goes away when they exit
+			defer func() {
+				if err := unmountAuto(m.Project); err != nil {
+					log.Warnf("%v", err)
+				}
+			}()
+		}
+		p = mountedProject(p, m.Mountpoint)
+	}
+
+	return editorService.OpenProject(editorName, p, window)
 }
@@ -18,7 +18,10 @@ package command
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/filipenos/projects/pkg/project"
 	"github.com/spf13/cobra"
 )
 
@@ -77,3 +80,72 @@ func init() {
 
 	rootCmd.AddCommand(completionCmd)
 }
+
+// completeProjectNames completes the first argument with project names and
+// aliases, described by the project type.
+func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return projectCompletions(projects, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func projectCompletions(projects project.Projects, toComplete string) []string {
+	var completions []string
+	for i := range projects {
+		p := &projects[i]
+		description := string(p.ProjectType)
+		if p.IsWorkspace {
+			description += " (w)"
+		}
+		for _, name := range p.Names() {
+			if !strings.HasPrefix(name, toComplete) {
+				continue
+			}
+			if name != p.Name {
+				completions = append(completions, fmt.Sprintf("%s\t%s, alias of %s", name, description, p.Name))
+				continue
+			}
+			completions = append(completions, fmt.Sprintf("%s\t%s", name, description))
+		}
+	}
+	sort.Strings(completions)
+	return completions
+}
+
+// completeFixedValues returns a completion function for a closed set of values.
+func completeFixedValues(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeProjectField completes flag values with the distinct values returned
+// by field for every project, e.g. groups or tags.
+func completeProjectField(field func(p *project.Project) []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		projects, err := project.Load(cfg)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		seen := map[string]bool{}
+		var values []string
+		for i := range projects {
+			for _, v := range field(&projects[i]) {
+				if v != "" && !seen[v] && strings.HasPrefix(v, toComplete) {
+					seen[v] = true
+					values = append(values, v)
+				}
+			}
+		}
+		sort.Strings(values)
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func projectGroups(p *project.Project) []string { return []string{p.Group} }
+func projectTags(p *project.Project) []string   { return p.Tags }
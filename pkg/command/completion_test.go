@@ -0,0 +1,39 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func TestProjectCompletions(t *testing.T) {
+	projects := project.Projects{
+		{Name: "api", Aliases: project.Aliases{"backend"}, ProjectType: project.ProjectTypeSSH},
+		{Name: "app", ProjectType: project.ProjectTypeLocal, IsWorkspace: true},
+		{Name: "web", ProjectType: project.ProjectTypeLocal},
+	}
+
+	got := projectCompletions(projects, "a")
+	expected := []string{"api\tssh", "app\tlocal (w)"}
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Fatalf("unexpected completions: %q", got)
+	}
+
+	got = projectCompletions(projects, "back")
+	if len(got) != 1 || got[0] != "backend\tssh, alias of api" {
+		t.Fatalf("expected alias completion, got %q", got)
+	}
+}
+
+func TestCompleteSessionBackend(t *testing.T) {
+	got, _ := completeSession(nil, []string{"--backend"}, "")
+	if len(got) == 0 || got[0] != "screen" {
+		t.Fatalf("expected backend names, got %v", got)
+	}
+
+	got, directive := completeSession(nil, []string{"-b", "tmux", "proj"}, "")
+	if got != nil || directive != cobra.ShellCompDirectiveDefault {
+		t.Fatalf("expected no completion after project, got %v", got)
+	}
+}
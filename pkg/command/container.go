@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// containerShell starts bash when the image has it, containers rarely set
+// $SHELL.
+const containerShell = `"$(command -v bash || command -v sh)"`
+
+// containerCommand runs remoteCmd in the project container with docker exec.
+func containerCommand(ctx context.Context, p *project.Project, remoteCmd string, mode sshMode) (*exec.Cmd, error) {
+	if err := path.EnsureExecutable("docker"); err != nil {
+		return nil, err
+	}
+	c, dir, err := p.ContainerInfo()
+	if err != nil {
+		return nil, err
+	}
+	name, err := resolveContainer(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, "docker", dockerExecArgs(name, dir, remoteCmd, mode)...), nil
+}
+
+func dockerExecArgs(container, dir, remoteCmd string, mode sshMode) []string {
+	args := []string{"exec"}
+	if mode != sshBatch {
+		args = append(args, "-it")
+	}
+	return append(args, "-w", dir, container, "sh", "-c", remoteCmd)
+}
+
+// resolveContainer finds the running dev container of a host folder through
+// the label set by the devcontainer CLI and VS Code.
+func resolveContainer(ctx context.Context, c project.Container) (string, error) {
+	if c.Name != "" {
+		return c.Name, nil
+	}
+	out, err := exec.CommandContext(ctx, "docker", "ps", "--format", "{{.ID}} {{.Names}}", "--filter", "label=devcontainer.local_folder="+c.LocalFolder).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers: %w", err)
+	}
+	return pickContainer(string(out), c.LocalFolder)
+}
+
+// pickContainer returns the id of the only container in the docker ps output,
+// refusing to guess when several dev containers use the same folder.
+func pickContainer(out, folder string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return "", fmt.Errorf("no running dev container for %s, start it with VS Code or the devcontainer CLI", folder)
+	}
+	if len(lines) > 1 {
+		return "", fmt.Errorf("%d running dev containers for %s: %s; stop the extra ones or use docker://CONTAINER/PATH", len(lines), folder, strings.Join(lines, ", "))
+	}
+	id, _, _ := strings.Cut(lines[0], " ")
+	return id, nil
+}
+
+// containerLabel names the container in messages without calling docker.
+func containerLabel(c project.Container) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return "devcontainer " + filepath.Base(c.LocalFolder)
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestDockerExecArgs(t *testing.T) {
+	tests := []struct {
+		mode     sshMode
+		expected string
+	}{
+		{sshBatch, "exec -w /srv/app web sh -c ls"},
+		{sshTerminal, "exec -it -w /srv/app web sh -c ls"},
+		{sshInteractive, "exec -it -w /srv/app web sh -c ls"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(dockerExecArgs("web", "/srv/app", "ls", tt.mode), " "); got != tt.expected {
+			t.Errorf("mode %d: got %q, want %q", tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestContainerProjectsAreRemote(t *testing.T) {
+	p := &project.Project{Name: "app", RootPath: "docker://web/srv/app"}
+	p.Init()
+	if !isRemote(p) || runsLocally(p) {
+		t.Fatal("expected container project to be remote")
+	}
+	name, dir, err := remoteTarget(p)
+	if err != nil || name != "web" || dir != "/srv/app" {
+		t.Fatalf("unexpected target: %s %s %v", name, dir, err)
+	}
+
+	if got := containerLabel(project.Container{LocalFolder: "/home/user/app"}); got != "devcontainer app" {
+		t.Fatalf("unexpected label: %s", got)
+	}
+}
+
+func TestPickContainer(t *testing.T) {
+	if id, err := pickContainer("a1b2 app_devcontainer-app-1\n", "/src/app"); err != nil || id != "a1b2" {
+		t.Fatalf("unexpected result: %q %v", id, err)
+	}
+	if _, err := pickContainer("", "/src/app"); err == nil {
+		t.Fatal("expected error without containers")
+	}
+	_, err := pickContainer("a1b2 old\nc3d4 new\n", "/src/app")
+	if err == nil || !strings.Contains(err.Error(), "a1b2 old, c3d4 new") {
+		t.Fatalf("expected error listing candidates, got %v", err)
+	}
+}
@@ -2,12 +2,13 @@ package command
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
+	"github.com/filipenos/projects/pkg/git"
 	"github.com/filipenos/projects/pkg/log"
 	"github.com/filipenos/projects/pkg/path"
 	"github.com/filipenos/projects/pkg/project"
+	"github.com/filipenos/projects/pkg/sshconfig"
 	"github.com/spf13/cobra"
 )
 
@@ -17,9 +18,12 @@ func init() {
 		Aliases: []string{"add"},
 		Short:   "Create new project",
 		RunE:    create,
+
+		ValidArgsFunction: completeCreateArgs,
 	}
 	cmd.Flags().Bool("editor", false, "Edit project fields before saving")
 	cmd.Flags().Bool("no-validate", false, "Skip path validation")
+	cmd.Flags().String("alias", "", "Comma separated list of aliases for the project")
 	rootCmd.AddCommand(cmd)
 }
 
@@ -42,13 +46,10 @@ func create(cmdParam *cobra.Command, params []string) error {
 		return fmt.Errorf("usage: projects create [name] [path]")
 	}
 
-	if p.RootPath != "" && path.Exist(fmt.Sprintf("%s/.git", p.RootPath)) {
-		cmd := exec.Command("git", "-C", p.RootPath, "remote", "get-url", "origin")
-		out, _ := cmd.CombinedOutput()
-		if scm := strings.TrimSpace(string(out)); scm != "" {
-			p.SCM = scm
-		}
+	p.Aliases = project.ParseAliases(SafeStringFlag(cmdParam, "alias"))
 
+	if git.IsRepo(p.RootPath) {
+		p.SCM = git.RemoteURL(p.RootPath)
 	}
 
 	if SafeBoolFlag(cmdParam, "editor") {
@@ -63,11 +64,8 @@ func create(cmdParam *cobra.Command, params []string) error {
 	}
 
 	if !SafeBoolFlag(cmdParam, "no-validate") {
-		if p.RootPath == "" {
-			return project.ErrPathRequired
-		}
-		if !path.Exist(p.RootPath) {
-			return project.ErrPathNoExist
+		if err := validateProjectPath(p); err != nil {
+			return err
 		}
 	}
 
@@ -76,8 +74,8 @@ func create(cmdParam *cobra.Command, params []string) error {
 		return err
 	}
 
-	if p, _ := projects.Get(p.Name); p != nil {
-		return fmt.Errorf("project '%s' already exists", p.Name)
+	if err := projects.CheckConflicts(p, -1); err != nil {
+		return err
 	}
 
 	projects = append(projects, *p)
@@ -88,3 +86,46 @@ func create(cmdParam *cobra.Command, params []string) error {
 
 	return nil
 }
+
+// validateProjectPath checks that local paths exist and warns about SSH hosts
+// missing from the ssh config, remote paths are not checked.
+func validateProjectPath(p *project.Project) error {
+	if p.RootPath == "" {
+		return project.ErrPathRequired
+	}
+	p.Init()
+	if p.ProjectType == project.ProjectTypeLocal {
+		if !path.Exist(p.RootPath) {
+			return project.ErrPathNoExist
+		}
+		return nil
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if p.ProjectType == project.ProjectTypeSSH {
+		if err := p.CheckSSHHost(); err != nil {
+			log.Warnf("%v", err)
+		}
+	}
+	return nil
+}
+
+// completeCreateArgs completes a path starting with "vscode-remote" with the
+// hosts of the ssh config, other paths complete as files.
+func completeCreateArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 1 || !strings.HasPrefix(toComplete, "vscode-remote") {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	c, err := sshconfig.LoadDefault()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var uris []string
+	for _, alias := range c.Aliases() {
+		if uri := sshURIPrefix + alias + "/"; strings.HasPrefix(uri, toComplete) {
+			uris = append(uris, uri)
+		}
+	}
+	return uris, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
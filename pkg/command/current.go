@@ -0,0 +1,41 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "current",
+		Short: "Print the project of the current directory",
+		Args:  cobra.NoArgs,
+		RunE:  current,
+	}
+	cmd.Flags().Bool("path", false, "Print the project path instead of the name")
+	rootCmd.AddCommand(cmd)
+}
+
+func current(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, pwd := path.CurrentPwd()
+	p, _ := projects.Resolve(pwd)
+	if p == nil {
+		return fmt.Errorf("no project found for '%s'", pwd)
+	}
+
+	if SafeBoolFlag(cmdParam, "path") {
+		log.Println(p.RootPath)
+		return nil
+	}
+	log.Println(p.Name)
+	return nil
+}
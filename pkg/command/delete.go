@@ -18,6 +18,8 @@ var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a existent project",
 	RunE:  delete,
+
+	ValidArgsFunction: completeProjectNames,
 }
 
 func delete(cmdParam *cobra.Command, params []string) error {
@@ -45,6 +47,13 @@ func delete(cmdParam *cobra.Command, params []string) error {
 		return fmt.Errorf("project '%s' not found", name)
 	}
 
+	// worktrees of the deleted project become regular projects
+	for i := range aux {
+		if aux[i].Parent == name {
+			aux[i].Parent = ""
+		}
+	}
+
 	log.Infof("Project '%s' removed successfully!", name)
 
 	projects = aux
@@ -0,0 +1,298 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that the projects are usable",
+		Long: `Check that the projects are usable: local paths exist and SSH hosts are
+configured.
+
+With --remote every SSH host is checked concurrently instead: DNS resolution,
+TCP reachability of the ssh port, a non interactive ssh login and the
+existence of the remote project paths.`,
+		Args:         cobra.NoArgs,
+		RunE:         doctor,
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool("remote", false, "Check the connectivity of the SSH projects")
+	cmd.Flags().Duration("timeout", 10*time.Second, "Time limit for the checks of each host")
+	cmd.Flags().IntP("jobs", "j", 8, "Maximum number of hosts checked at the same time")
+	cmd.Flags().String("group", "", "Check only projects of the group")
+	cmd.Flags().StringSlice("tag", nil, "Check only projects with the tag (repeat for AND)")
+	cmd.RegisterFlagCompletionFunc("group", completeProjectField(projectGroups))
+	cmd.RegisterFlagCompletionFunc("tag", completeProjectField(projectTags))
+	rootCmd.AddCommand(cmd)
+}
+
+func doctor(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	projects = selectProjects(projects, SafeStringFlag(cmdParam, "group"), SafeStringSliceFlag(cmdParam, "tag"))
+
+	if SafeBoolFlag(cmdParam, "remote") {
+		timeout, _ := cmdParam.Flags().GetDuration("timeout")
+		jobs, _ := cmdParam.Flags().GetInt("jobs")
+		return doctorRemote(projects, timeout, jobs)
+	}
+
+	failed := 0
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tTYPE\tRESULT")
+	for i := range projects {
+		result := "ok"
+		if err := checkProject(&projects[i]); err != nil {
+			failed++
+			result = err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", projects[i].Name, projects[i].ProjectType, result)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d project(s) have problems", failed, len(projects))
+	}
+	return nil
+}
+
+// checkProject validates the project and, for SSH projects, that the host is
+// known to ssh.
+func checkProject(p *project.Project) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if p.ProjectType == project.ProjectTypeSSH {
+		return p.CheckSSHHost()
+	}
+	return nil
+}
+
+func doctorRemote(projects project.Projects, timeout time.Duration, jobs int) error {
+	hosts := groupSSHHosts(projects)
+	if len(hosts) == 0 {
+		return fmt.Errorf("no SSH projects match the given filters")
+	}
+
+	runParallel(context.Background(), len(hosts), jobs, false, func(ctx context.Context, i int) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		hosts[i].run(ctx, timeout)
+		return nil
+	})
+
+	failed, total := 0, 0
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tHOST\tDNS\tTCP\tSSH\tPATH")
+	for _, h := range hosts {
+		for i, p := range h.projects {
+			total++
+			if !h.paths[i].ok() {
+				failed++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.Name, h.host, h.dns.status, h.tcp.status, h.login.status, h.paths[i].status)
+		}
+	}
+	w.Flush()
+
+	for _, h := range hosts {
+		for _, c := range []struct {
+			name   string
+			result checkResult
+		}{{"dns", h.dns}, {"tcp", h.tcp}, {"ssh", h.login}} {
+			if c.result.err != nil {
+				log.Warnf("%s: %s: %v", h.host, c.name, c.result.err)
+			}
+		}
+		for i, p := range h.projects {
+			if h.paths[i].err != nil {
+				log.Warnf("%s: %v", p.Name, h.paths[i].err)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d SSH project(s) are not reachable", failed, total)
+	}
+	log.Infof("%d SSH project(s) reachable", total)
+	return nil
+}
+
+const (
+	checkOK      = "ok"
+	checkFailed  = "failed"
+	checkSkipped = "skipped"
+)
+
+type checkResult struct {
+	status string
+	err    error
+}
+
+func (r checkResult) ok() bool {
+	return r.status == checkOK
+}
+
+func passed() checkResult {
+	return checkResult{status: checkOK}
+}
+
+func failedWith(err error) checkResult {
+	return checkResult{status: checkFailed, err: err}
+}
+
+func skipped() checkResult {
+	return checkResult{status: checkSkipped}
+}
+
+// hostCheck holds the checks of one ssh destination, shared by the projects
+// on it with the same ssh options so each login is only tried once.
+type hostCheck struct {
+	host     string
+	projects []*project.Project
+	dirs     []string
+
+	dns, tcp, login checkResult
+	paths           []checkResult
+}
+
+// groupSSHHosts groups the SSH projects by destination and ssh options, since
+// the user, identity file or jump host change the login.
+func groupSSHHosts(projects project.Projects) []*hostCheck {
+	var hosts []*hostCheck
+	byHost := map[string]*hostCheck{}
+	for i := range projects {
+		p := &projects[i]
+		if p.ProjectType != project.ProjectTypeSSH {
+			continue
+		}
+		host, dir, err := p.SSHInfo()
+		if err != nil {
+			continue
+		}
+		key := strings.Join(append([]string{host}, p.SSH.Args()...), "\x00")
+		h, ok := byHost[key]
+		if !ok {
+			h = &hostCheck{host: host}
+			byHost[key] = h
+			hosts = append(hosts, h)
+		}
+		h.projects = append(h.projects, p)
+		h.dirs = append(h.dirs, dir)
+	}
+	return hosts
+}
+
+func (h *hostCheck) run(ctx context.Context, timeout time.Duration) {
+	h.dns, h.tcp, h.login = skipped(), skipped(), skipped()
+	h.paths = make([]checkResult, len(h.projects))
+	for i := range h.paths {
+		h.paths[i] = skipped()
+	}
+
+	p := h.projects[0]
+	r, err := p.ResolveSSH()
+	if err != nil {
+		h.login = failedWith(err)
+		return
+	}
+
+	// behind a jump host the target is usually only reachable from it, so
+	// only the ssh login tells something
+	if r.ProxyJump == "" && r.ProxyCommand == "" && (p.SSH == nil || p.SSH.JumpHost == "") {
+		h.dns = checkDNS(ctx, r.HostName)
+		if !h.dns.ok() {
+			return
+		}
+		h.tcp = checkTCP(ctx, net.JoinHostPort(r.HostName, r.Port))
+		if !h.tcp.ok() {
+			return
+		}
+	}
+
+	h.login, h.paths = checkRemotePaths(ctx, p, h.host, h.dirs, timeout)
+}
+
+func checkDNS(ctx context.Context, hostname string) checkResult {
+	if net.ParseIP(hostname) != nil {
+		return passed()
+	}
+	if _, err := net.DefaultResolver.LookupHost(ctx, hostname); err != nil {
+		return failedWith(err)
+	}
+	return passed()
+}
+
+func checkTCP(ctx context.Context, address string) checkResult {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return failedWith(err)
+	}
+	conn.Close()
+	return passed()
+}
+
+// checkRemotePaths logs in without prompts and tests every directory in the
+// same connection.
+func checkRemotePaths(ctx context.Context, p *project.Project, host string, dirs []string, timeout time.Duration) (checkResult, []checkResult) {
+	paths := make([]checkResult, len(dirs))
+	for i := range paths {
+		paths[i] = skipped()
+	}
+
+	script := fmt.Sprintf(`for d in %s; do if test -d "$d"; then echo ok; else echo missing; fi; done`, quoteArgs(dirs))
+	seconds := int(timeout.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	options := append(p.SSH.Args(), "-o", "BatchMode=yes", "-o", "ConnectTimeout="+strconv.Itoa(seconds))
+	name, args := sshArgs(project.TransportSSH, options, host, quoteArgs([]string{"sh", "-c", script}), sshBatch)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := lastLine(stderr.String()); msg != "" {
+			err = fmt.Errorf("%s", msg)
+		} else if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return failedWith(err), paths
+	}
+
+	lines := strings.Fields(stdout.String())
+	if len(lines) != len(dirs) {
+		return failedWith(fmt.Errorf("unexpected output checking remote paths: %q", stdout.String())), paths
+	}
+	for i, line := range lines {
+		if line == "ok" {
+			paths[i] = passed()
+		} else {
+			paths[i] = failedWith(fmt.Errorf("remote path %s not found", dirs[i]))
+		}
+	}
+	return passed(), paths
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
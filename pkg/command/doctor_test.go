@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// fakeSSH stands in for ssh and sshd: logins to "denied" fail like ssh does
+// and every other remote command runs locally.
+const fakeSSH = `#!/bin/sh
+prev=""
+for arg; do
+	host="$prev"
+	prev="$arg"
+done
+case " $* " in
+	*" BatchMode=yes "*) ;;
+	*) echo "missing BatchMode" >&2; exit 2 ;;
+esac
+if [ "$host" = "denied" ]; then
+	echo "denied: Permission denied (publickey)." >&2
+	exit 255
+fi
+exec sh -c "$prev"
+`
+
+func TestDoctorRemoteChecks(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeTestFile(t, filepath.Join(home, ".ssh", "config"), fmt.Sprintf(`
+Host good denied
+    HostName 127.0.0.1
+    Port %d
+Host closed
+    HostName 127.0.0.1
+    Port %d
+Host nodns
+    HostName nodns.invalid
+Host jumped
+    HostName internal.invalid
+    ProxyJump bastion
+`, port, closedPort))
+
+	bin := t.TempDir()
+	writeTestFile(t, filepath.Join(bin, "ssh"), fakeSSH)
+	if err := os.Chmod(filepath.Join(bin, "ssh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	existing := t.TempDir()
+	missing := filepath.Join(existing, "missing")
+	projects := project.Projects{
+		{Name: "api", RootPath: "vscode-remote://ssh-remote+good" + existing},
+		{Name: "web", RootPath: "vscode-remote://ssh-remote+good" + missing},
+		{Name: "secret", RootPath: "vscode-remote://ssh-remote+denied" + existing},
+		{Name: "down", RootPath: "vscode-remote://ssh-remote+closed" + existing},
+		{Name: "typo", RootPath: "vscode-remote://ssh-remote+nodns" + existing},
+		{Name: "inside", RootPath: "vscode-remote://ssh-remote+jumped" + existing},
+		{Name: "local", RootPath: existing},
+		{Name: "keyed", RootPath: "vscode-remote://ssh-remote+good" + existing, SSH: &project.SSHOptions{IdentityFile: "/keys/other"}},
+	}
+	for i := range projects {
+		projects[i].Init()
+	}
+
+	hosts := groupSSHHosts(projects)
+	if len(hosts) != 6 {
+		t.Fatalf("expected 6 hosts, got %d", len(hosts))
+	}
+	if len(hosts[0].projects) != 2 || len(hosts[5].projects) != 1 || hosts[5].projects[0].Name != "keyed" {
+		t.Fatalf("expected projects with other ssh options to be checked apart")
+	}
+	for _, h := range hosts {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		h.run(ctx, 5*time.Second)
+		cancel()
+	}
+
+	expected := map[string]string{
+		"api":    "ok ok ok ok",
+		"web":    "ok ok ok failed",
+		"secret": "ok ok failed skipped",
+		"down":   "ok failed skipped skipped",
+		"typo":   "failed skipped skipped skipped",
+		"inside": "skipped skipped ok ok",
+		"keyed":  "ok ok ok ok",
+	}
+	for _, h := range hosts {
+		for i, p := range h.projects {
+			got := fmt.Sprintf("%s %s %s %s", h.dns.status, h.tcp.status, h.login.status, h.paths[i].status)
+			if got != expected[p.Name] {
+				t.Errorf("%s: got %q, want %q", p.Name, got, expected[p.Name])
+			}
+		}
+	}
+	if err := hosts[1].login.err; err == nil || err.Error() != "denied: Permission denied (publickey)." {
+		t.Errorf("expected ssh error message, got %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
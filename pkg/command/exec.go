@@ -1,23 +1,29 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/filipenos/projects/pkg/log"
-	"github.com/filipenos/projects/pkg/path"
 	"github.com/filipenos/projects/pkg/project"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	cmd := &cobra.Command{
-		Use:                "exec",
+		Use:                "exec [project] [--] <command...>",
 		Short:              "Exec command inside your project",
 		DisableFlagParsing: true,
 		RunE:               execCmd,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeProjectNames(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveDefault
+		},
 	}
 	rootCmd.AddCommand(cmd)
 }
@@ -28,51 +34,60 @@ func execCmd(cmdParam *cobra.Command, params []string) error {
 		return err
 	}
 
-	p, _ := projects.Find(path.SafeName(params...))
-	if p == nil {
-		return fmt.Errorf("project not found")
-
+	p, params, err := projectAndArgs(projects, params)
+	if err != nil {
+		return err
 	}
 	if err := p.Validate(); err != nil {
 		return err
 	}
-	if len(params) < 2 {
+	if len(params) == 0 {
 		return fmt.Errorf("missing command to execute inside project")
 	}
 
+	env, err := projectEnvironment(p, nil)
+	if err != nil {
+		return err
+	}
+
 	var (
-		command = params[1]
+		command = params[0]
 		args    []string
 		workDir string
+		cmdEnv  []string
 	)
 
-	switch p.ProjectType {
-	case project.ProjectTypeLocal:
-		workDir = p.RootPath
-		if len(params) > 2 {
-			args = params[2:]
-		}
+	switch {
+	case runsLocally(p):
+		workDir = projectWorkingDir(p)
+		cmdEnv = env
+		args = params[1:]
 
-	case project.ProjectTypeSSH:
-		log.Infof("executing on ssh host")
-		sshHost, sshPath, err := p.SSHInfo()
+	case isRemote(p):
+		log.Infof("executing on %s host", p.ProjectType)
+		_, sshPath, err := remoteTarget(p)
 		if err != nil {
 			return err
 		}
 
 		// Build remote command with properly quoted arguments
 		var remoteCmd strings.Builder
-		remoteCmd.WriteString(fmt.Sprintf("cd %s && %s", shellQuote(sshPath), shellQuote(command)))
-		if len(params) > 2 {
-			for _, arg := range params[2:] {
-				remoteCmd.WriteString(" ")
-				remoteCmd.WriteString(shellQuote(arg))
-			}
+		remoteCmd.WriteString(fmt.Sprintf("cd %s && ", shellQuote(sshPath)))
+		if len(env) > 0 {
+			remoteCmd.WriteString(exportCommand(env) + " && ")
+		}
+		remoteCmd.WriteString(shellQuote(command))
+		for _, arg := range params[1:] {
+			remoteCmd.WriteString(" ")
+			remoteCmd.WriteString(shellQuote(arg))
 		}
 
-		args = []string{sshHost, "-t", remoteCmd.String()}
-		command = "ssh"
-		workDir = "" // SSH doesn't use local workDir
+		sshCmd, err := remoteCommand(context.Background(), p, remoteCmd.String(), sshTerminal)
+		if err != nil {
+			return err
+		}
+		command, args = sshCmd.Path, sshCmd.Args[1:]
+		workDir = "" // remote projects don't use local workDir
 
 	default:
 		return fmt.Errorf("project type %s not supported for exec command", p.ProjectType)
@@ -80,6 +95,9 @@ func execCmd(cmdParam *cobra.Command, params []string) error {
 
 	cmd := exec.Command(command, args...)
 	cmd.Dir = workDir
+	if len(cmdEnv) > 0 {
+		cmd.Env = append(os.Environ(), cmdEnv...)
+	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
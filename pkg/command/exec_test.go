@@ -0,0 +1,56 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func TestExecFromSubdirectory(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "api")
+	sub := filepath.Join(root, "internal", "db")
+	other := filepath.Join(base, "db")
+	for _, dir := range []string{sub, other} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+
+	original := cfg
+	defer func() { cfg = original }()
+	cfg.ProjectLocation = filepath.Join(base, "projects.json")
+	projects := project.Projects{
+		{Name: "api", RootPath: root, Enabled: true},
+		{Name: "db", RootPath: other, Enabled: true},
+	}
+	if err := projects.Save(cfg); err != nil {
+		t.Fatalf("failed to save projects: %v", err)
+	}
+	t.Setenv("PWD", sub)
+
+	tests := []struct {
+		params []string
+		dir    string
+	}{
+		{[]string{"touch", "implicit"}, root},
+		{[]string{"--", "touch", "separator"}, root},
+		{[]string{"db", "touch", "named"}, other},
+	}
+	for _, tt := range tests {
+		if err := execCmd(&cobra.Command{}, tt.params); err != nil {
+			t.Fatalf("exec %v failed: %v", tt.params, err)
+		}
+		marker := filepath.Join(tt.dir, tt.params[len(tt.params)-1])
+		if _, err := os.Stat(marker); err != nil {
+			t.Fatalf("exec %v did not run in %s: %v", tt.params, tt.dir, err)
+		}
+	}
+
+	if err := execCmd(&cobra.Command{}, []string{"--"}); err == nil {
+		t.Fatal("expected error without a command")
+	}
+}
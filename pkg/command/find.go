@@ -0,0 +1,94 @@
+package command
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// findProject wraps Projects.Find asking the user to pick one of the matches
+// when the name is ambiguous and the command runs on a terminal.
+func findProject(projects project.Projects, name, pwd string) (*project.Project, int, error) {
+	p, pos, err := projects.Find(name, pwd)
+	var ambiguous *project.AmbiguousError
+	if !errors.As(err, &ambiguous) || !isTerminal(os.Stdin) {
+		return p, pos, err
+	}
+
+	choice, err := promptChoice(ambiguous.Matches)
+	if err != nil {
+		return nil, -1, err
+	}
+	p, pos = projects.Get(choice)
+	if p == nil {
+		return nil, -1, project.ErrProjectNotFound
+	}
+	return p, pos, nil
+}
+
+// projectAndArgs splits params into the project named by the first one and
+// the params after it. Inside a registered project the name is optional: with
+// no params, a leading "--" or a first param that is not the exact name or
+// alias of a project, the project of the current directory gets all of them.
+func projectAndArgs(projects project.Projects, params []string) (*project.Project, []string, error) {
+	_, pwd := path.CurrentPwd()
+	current, _ := projects.Resolve(pwd)
+
+	if len(params) == 0 || params[0] == "--" {
+		if current == nil {
+			return nil, nil, fmt.Errorf("no project found for '%s', give the project name", pwd)
+		}
+		if len(params) > 0 {
+			params = params[1:]
+		}
+		return current, params, nil
+	}
+	if current != nil {
+		if _, _, err := projects.Lookup(params[0]); err == project.ErrProjectNotFound {
+			return current, params, nil
+		}
+	}
+
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, params[1:], nil
+}
+
+func promptChoice(options []string) (string, error) {
+	fmt.Fprintln(os.Stderr, "Multiple projects match:")
+	for i, option := range options {
+		fmt.Fprintf(os.Stderr, "  %d) %s\n", i+1, option)
+	}
+	fmt.Fprint(os.Stderr, "Select a project: ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("no project selected")
+	}
+	line = strings.TrimSpace(line)
+	if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(options) {
+		return options[n-1], nil
+	}
+	for _, option := range options {
+		if option == line {
+			return option, nil
+		}
+	}
+	return "", fmt.Errorf("invalid selection '%s'", line)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -11,3 +11,8 @@ func SafeStringFlag(cmd *cobra.Command, flagName string) string {
 	v, _ := cmd.Flags().GetString(flagName)
 	return v
 }
+
+func SafeStringSliceFlag(cmd *cobra.Command, flagName string) []string {
+	v, _ := cmd.Flags().GetStringSlice(flagName)
+	return v
+}
@@ -0,0 +1,194 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "foreach [--group group] [--tag tag] -- <command> [args...]",
+		Short: "Run a command in every matching project in parallel",
+		Example: `projects foreach --group work -- git status -s
+projects foreach --tag go --jobs 8 --fail-fast -- go test ./...`,
+		RunE: foreach,
+
+		// a failing project is not a usage error
+		SilenceUsage: true,
+	}
+	cmd.Flags().String("group", "", "Run only on projects of the group")
+	cmd.Flags().StringSlice("tag", nil, "Run only on projects with the tag (repeat for AND)")
+	cmd.Flags().IntP("jobs", "j", 4, "Maximum number of commands running at the same time")
+	cmd.Flags().Bool("fail-fast", false, "Stop on the first failure, cancelling running commands")
+	cmd.RegisterFlagCompletionFunc("group", completeProjectField(projectGroups))
+	cmd.RegisterFlagCompletionFunc("tag", completeProjectField(projectTags))
+	rootCmd.AddCommand(cmd)
+}
+
+type foreachResult struct {
+	project  string
+	status   string
+	exitCode int
+	duration time.Duration
+}
+
+func foreach(cmdParam *cobra.Command, params []string) error {
+	if dash := cmdParam.ArgsLenAtDash(); dash > 0 {
+		return fmt.Errorf("unexpected arguments before '--': %s", strings.Join(params[:dash], " "))
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("missing command to execute, use: projects foreach [flags] -- <command>")
+	}
+
+	failFast := SafeBoolFlag(cmdParam, "fail-fast")
+	jobs, _ := cmdParam.Flags().GetInt("jobs")
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	selected := selectProjects(projects, SafeStringFlag(cmdParam, "group"), SafeStringSliceFlag(cmdParam, "tag"))
+	if len(selected) == 0 {
+		return fmt.Errorf("no projects match the given filters")
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make([]foreachResult, len(selected))
+		width   = longestName(selected)
+	)
+	for i := range selected {
+		results[i] = foreachResult{project: selected[i].Name, status: "not run", exitCode: -1}
+	}
+	runParallel(context.Background(), len(selected), jobs, failFast, func(ctx context.Context, i int) error {
+		p := &selected[i]
+		cmd, err := projectCommand(ctx, p, params)
+		if err != nil {
+			log.Warnf("%s: %v", p.Name, err)
+			results[i].status = "skipped"
+			return err
+		}
+
+		prefix := fmt.Sprintf("%-*s | ", width, p.Name)
+		stdout := newPrefixWriter(&mu, log.Output(), prefix)
+		stderr := newPrefixWriter(&mu, log.ErrorOutput(), prefix)
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+
+		start := time.Now()
+		err = cmd.Run()
+		stdout.Flush()
+		stderr.Flush()
+
+		results[i] = foreachResult{project: p.Name, status: "ok", duration: time.Since(start)}
+		if err != nil {
+			results[i].status, results[i].exitCode = "failed", exitCode(err)
+			if ctx.Err() != nil {
+				results[i].status = "cancelled"
+			}
+		}
+		return err
+	})
+
+	failed := printForeachSummary(results)
+	if failed > 0 {
+		return fmt.Errorf("command failed or was skipped on %d of %d project(s)", failed, len(results))
+	}
+	return nil
+}
+
+// selectProjects returns the projects matching group and all tags.
+func selectProjects(projects project.Projects, group string, tags []string) project.Projects {
+	var selected project.Projects
+	for i := range projects {
+		if matchesGroupAndTags(&projects[i], group, tags) {
+			selected = append(selected, projects[i])
+		}
+	}
+	return selected
+}
+
+// projectCommand builds the command that runs args inside the project: a
+// process in the project directory for local projects and an ssh invocation
+// for remote ones.
+func projectCommand(ctx context.Context, p *project.Project, args []string) (*exec.Cmd, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	env, err := projectEnvironment(p, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case runsLocally(p):
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = projectWorkingDir(p)
+		cmd.Env = append(os.Environ(), env...)
+		return cmd, nil
+
+	case isRemote(p):
+		_, sshPath, err := remoteTarget(p)
+		if err != nil {
+			return nil, err
+		}
+		remoteCmd := fmt.Sprintf("cd %s && ", shellQuote(sshPath))
+		if len(env) > 0 {
+			remoteCmd += exportCommand(env) + " && "
+		}
+		return remoteCommand(ctx, p, remoteCmd+quoteArgs(args), sshBatch)
+
+	default:
+		return nil, fmt.Errorf("project type %s not supported", p.ProjectType)
+	}
+}
+
+func printForeachSummary(results []foreachResult) (failed int) {
+	log.Println()
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tSTATUS\tEXIT\tDURATION")
+	for _, r := range results {
+		code := "-"
+		if r.exitCode >= 0 {
+			code = fmt.Sprint(r.exitCode)
+		}
+		duration := "-"
+		if r.duration > 0 {
+			duration = r.duration.Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.project, r.status, code, duration)
+		if r.status == "failed" || r.status == "cancelled" || r.status == "skipped" {
+			failed++
+		}
+	}
+	w.Flush()
+	return failed
+}
+
+func longestName(projects project.Projects) int {
+	width := 0
+	for _, p := range projects {
+		if len(p.Name) > width {
+			width = len(p.Name)
+		}
+	}
+	return width
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,15 @@
+package command
+
+import "testing"
+
+func TestPrintForeachSummaryCountsSkipped(t *testing.T) {
+	results := []foreachResult{
+		{project: "api", status: "ok", exitCode: 0},
+		{project: "web", status: "failed", exitCode: 2},
+		{project: "gone", status: "skipped", exitCode: -1},
+		{project: "db", status: "not run", exitCode: -1},
+	}
+	if failed := printForeachSummary(results); failed != 2 {
+		t.Fatalf("expected failed and skipped projects to count, got %d", failed)
+	}
+}
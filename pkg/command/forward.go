@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/filipenos/projects/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "forward <project>",
+		Short: "Open the forwarded ports of an SSH workspace in the background",
+		Long: `Open the ports listed in remote.SSH.defaultForwardedPorts of an SSH workspace
+as ssh tunnels running in the background, without a remote shell.`,
+		Args: cobra.ExactArgs(1),
+		RunE: forward,
+
+		ValidArgsFunction: completeProjectNames,
+	}
+	rootCmd.AddCommand(cmd)
+}
+
+func forward(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	host, _, err := p.SSHInfo()
+	if err != nil {
+		return err
+	}
+	if !p.IsWorkspace {
+		return fmt.Errorf("project '%s' is not a workspace, there are no forwarded ports", p.Name)
+	}
+
+	ports, err := forwardedPorts(p, host)
+	if err != nil {
+		return err
+	}
+	if len(ports) == 0 {
+		return fmt.Errorf("workspace '%s' has no remote.SSH.defaultForwardedPorts", p.Name)
+	}
+
+	// -f backgrounds ssh once the tunnels are up, failing when a port is taken
+	extra := append(forwardArgs(ports), "-f", "-N", "-o", "ExitOnForwardFailure=yes")
+	cmd, err := sshCommand(context.Background(), p, host, "", sshBatch, extra...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = cmdParam.InOrStdin()
+	cmd.Stdout = log.Output()
+	cmd.Stderr = log.ErrorOutput()
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to open tunnels to %s: %w", host, err)
+	}
+	reportForwards(host, ports)
+	return nil
+}
+
+// workspaceForwards returns the tunnels of an SSH workspace for interactive
+// connections. Failures only warn, the shell is still useful without them.
+func workspaceForwards(p *project.Project, host string) []string {
+	if !p.IsWorkspace || p.ProjectType != project.ProjectTypeSSH {
+		return nil
+	}
+	ports, err := forwardedPorts(p, host)
+	if err != nil {
+		log.Warnf("failed to read forwarded ports: %v", err)
+		return nil
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	if transport, _ := p.SSHTransport(cfg.SSHTransport); transport == project.TransportMosh {
+		log.Warnf("mosh can't forward ports, run 'projects forward %s' to open them", p.Name)
+		return nil
+	}
+	reportForwards(host, ports)
+	return forwardArgs(ports)
+}
+
+// forwardedPorts reads the workspace file from the remote host.
+func forwardedPorts(p *project.Project, host string) ([]workspace.RemoteSSHDefaultForwardedPorts, error) {
+	cmd, err := sshCommand(context.Background(), p, host, "cat "+shellQuote(p.Path), sshBatch)
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s on %s: %w", p.Path, host, err)
+	}
+	ws, err := workspace.Parse(p.Path, out)
+	if err != nil {
+		return nil, err
+	}
+	return ws.Settings.RemoteSSHDefaultForwardedPorts, nil
+}
+
+func forwardArgs(ports []workspace.RemoteSSHDefaultForwardedPorts) []string {
+	var args []string
+	for _, port := range ports {
+		if port.RemotePort == 0 {
+			continue
+		}
+		args = append(args, "-L", port.LocalForward())
+	}
+	return args
+}
+
+func reportForwards(host string, ports []workspace.RemoteSSHDefaultForwardedPorts) {
+	for _, port := range ports {
+		if port.RemotePort == 0 {
+			continue
+		}
+		label := ""
+		if port.Name != "" {
+			label = " (" + port.Name + ")"
+		}
+		log.Infof("forwarding localhost:%d to %s:%d%s", port.Local(), host, port.RemotePort, label)
+	}
+}
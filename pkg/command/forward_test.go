@@ -0,0 +1,26 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/filipenos/projects/pkg/workspace"
+)
+
+func TestForwardArgs(t *testing.T) {
+	ports := []workspace.RemoteSSHDefaultForwardedPorts{
+		{Name: "web", LocalPort: 8080, RemotePort: 3000},
+		{Name: "broken"},
+		{RemotePort: 5432},
+	}
+	expected := "-L 8080:localhost:3000 -L 5432:localhost:5432"
+	if got := strings.Join(forwardArgs(ports), " "); got != expected {
+		t.Fatalf("unexpected args: %s", got)
+	}
+
+	name, args := sshArgs(project.TransportSSH, []string{"-f", "-N"}, "dev", "", sshBatch)
+	if got := name + " " + strings.Join(args, " "); got != "ssh -f -N dev" {
+		t.Fatalf("expected no remote command, got %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/filipenos/projects/pkg/git"
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	gitCmd := &cobra.Command{
+		Use:   "git",
+		Short: "Run git operations over the local projects",
+	}
+	gitCmd.PersistentFlags().String("group", "", "Run only on projects of the group")
+	gitCmd.PersistentFlags().StringSlice("tag", nil, "Run only on projects with the tag (repeat for AND)")
+	gitCmd.PersistentFlags().IntP("jobs", "j", 8, "Maximum number of repositories updated at the same time")
+	gitCmd.RegisterFlagCompletionFunc("group", completeProjectField(projectGroups))
+	gitCmd.RegisterFlagCompletionFunc("tag", completeProjectField(projectTags))
+
+	pullCmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Fast-forward projects to their upstream, skipping dirty or diverged ones",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !SafeBoolFlag(cmd, "ff-only") {
+				return fmt.Errorf("only fast-forward pulls are supported")
+			}
+			return runGitOperation(cmd, git.Pull)
+		},
+		SilenceUsage: true,
+	}
+	pullCmd.Flags().Bool("ff-only", true, "Only fast-forward (the only supported mode)")
+
+	gitCmd.AddCommand(
+		&cobra.Command{
+			Use:          "fetch",
+			Short:        "Fetch the remotes of the projects",
+			Args:         cobra.NoArgs,
+			RunE:         func(cmd *cobra.Command, args []string) error { return runGitOperation(cmd, git.Fetch) },
+			SilenceUsage: true,
+		},
+		pullCmd,
+		&cobra.Command{
+			Use:          "push",
+			Short:        "Push projects ahead of their upstream, skipping non fast-forward ones",
+			Args:         cobra.NoArgs,
+			RunE:         func(cmd *cobra.Command, args []string) error { return runGitOperation(cmd, git.Push) },
+			SilenceUsage: true,
+		},
+	)
+	rootCmd.AddCommand(gitCmd)
+}
+
+type gitOperationResult struct {
+	project string
+	result  git.Result
+	err     error
+}
+
+func runGitOperation(cmdParam *cobra.Command, operation func(ctx context.Context, dir string) (git.Result, error)) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	repos := gitProjects(selectProjects(projects, SafeStringFlag(cmdParam, "group"), SafeStringSliceFlag(cmdParam, "tag")))
+	if len(repos) == 0 {
+		return fmt.Errorf("no git projects match the given filters")
+	}
+	jobs, _ := cmdParam.Flags().GetInt("jobs")
+
+	results := make([]gitOperationResult, len(repos))
+	runParallel(context.Background(), len(repos), jobs, false, func(ctx context.Context, i int) error {
+		r, err := operation(ctx, projectWorkingDir(&repos[i]))
+		results[i] = gitOperationResult{project: repos[i].Name, result: r, err: err}
+		return err
+	})
+
+	failed := 0
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tRESULT\tDETAIL")
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\terror\t%v\n", r.project, r.err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.project, r.result.Outcome, r.result.Reason)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("git %s failed on %d of %d project(s)", cmdParam.Name(), failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "init-shell <bash|zsh|fish|nu>",
+		Short: "Print a shell function that cd's into projects",
+		Long: `Print a shell function that changes the current shell directory to the project,
+keeping history and job control instead of spawning a new shell.
+
+# ~/.bashrc or ~/.zshrc
+eval "$(projects init-shell bash)"
+
+# ~/.config/fish/config.fish
+projects init-shell fish | source
+
+# nushell: save the output and source it from config.nu
+projects init-shell nu | save -f ~/.config/nushell/projects.nu
+`,
+		ValidArgs: []string{"bash", "zsh", "fish", "nu"},
+		Args:      cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script, err := shellWrapper(args[0], SafeStringFlag(cmd, "name"))
+			if err != nil {
+				return err
+			}
+			log.Printf("%s", script)
+			return nil
+		},
+	}
+	cmd.Flags().String("name", "p", "Name of the generated function")
+	rootCmd.AddCommand(cmd)
+}
+
+func shellWrapper(shell, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("function name is required")
+	}
+
+	switch shell {
+	case "bash", "zsh":
+		return fmt.Sprintf(`%[1]s() {
+  local dir
+  dir="$(command projects path "$@")" || return
+  cd -- "$dir"
+}
+`, name), nil
+
+	case "fish":
+		return fmt.Sprintf(`function %[1]s
+    set -l dir (command projects path $argv); or return
+    cd $dir
+end
+`, name), nil
+
+	case "nu":
+		return fmt.Sprintf(`def --env %[1]s [...args: string] {
+    let dir = (^projects path ...$args | str trim)
+    cd $dir
+}
+`, name), nil
+
+	default:
+		return "", fmt.Errorf("shell '%s' not supported (available: bash, zsh, fish, nu)", shell)
+	}
+}
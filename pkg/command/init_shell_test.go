@@ -0,0 +1,25 @@
+package command
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShellWrapper(t *testing.T) {
+	for _, sh := range []string{"bash", "zsh", "fish", "nu"} {
+		script, err := shellWrapper(sh, "goto")
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", sh, err)
+		}
+		if !strings.Contains(script, "goto") || !strings.Contains(script, "projects path") {
+			t.Fatalf("unexpected %s wrapper: %s", sh, script)
+		}
+	}
+
+	if _, err := shellWrapper("powershell", "p"); err == nil {
+		t.Fatalf("expected error for unsupported shell")
+	}
+	if _, err := shellWrapper("bash", " "); err == nil {
+		t.Fatalf("expected error for empty function name")
+	}
+}
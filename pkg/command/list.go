@@ -13,6 +13,8 @@ var (
 	listSSH       bool
 	listLocal     bool
 	listWorkspace bool
+	listGroup     string
+	listTags      []string
 )
 
 // listCmd represents the list command
@@ -27,6 +29,10 @@ func init() {
 	listCmd.Flags().BoolVar(&listSSH, "ssh", false, "List only SSH projects")
 	listCmd.Flags().BoolVar(&listLocal, "local", false, "List only local projects")
 	listCmd.Flags().BoolVar(&listWorkspace, "workspace", false, "List only workspace projects")
+	listCmd.Flags().StringVar(&listGroup, "group", "", "List only projects of the group")
+	listCmd.Flags().StringSliceVar(&listTags, "tag", nil, "List only projects with the tag (repeat for AND)")
+	listCmd.RegisterFlagCompletionFunc("group", completeProjectField(projectGroups))
+	listCmd.RegisterFlagCompletionFunc("tag", completeProjectField(projectTags))
 	rootCmd.AddCommand(listCmd)
 }
 
@@ -36,11 +42,13 @@ func list(cmdParam *cobra.Command, params []string) error {
 		return fmt.Errorf("error on load file: %v", err)
 	}
 	sort.Sort(projects)
+	markAttached(projects)
 
 	// If no filters are specified, show all
 	showAll := !listSSH && !listLocal && !listWorkspace
 
-	for _, p := range projects {
+	indent := map[string]string{}
+	for _, p := range orderByParent(projects) {
 		// Apply filters with AND logic
 		if !showAll {
 			// Check SSH filter
@@ -57,7 +65,16 @@ func list(cmdParam *cobra.Command, params []string) error {
 			}
 		}
 
-		print := fmt.Sprintf("%s %s", p.Name, string(p.ProjectType))
+		if !matchesGroupAndTags(&p, listGroup, listTags) {
+			continue
+		}
+
+		if prefix, ok := indent[p.Parent]; ok && p.Parent != "" {
+			indent[p.Name] = prefix + "  "
+		} else {
+			indent[p.Name] = ""
+		}
+		print := fmt.Sprintf("%s%s %s", indent[p.Name], p.Name, string(p.ProjectType))
 		if p.IsWorkspace {
 			print += " (w)"
 		}
@@ -75,3 +92,55 @@ func list(cmdParam *cobra.Command, params []string) error {
 
 	return nil
 }
+
+func matchesGroupAndTags(p *project.Project, group string, tags []string) bool {
+	if group != "" && p.Group != group {
+		return false
+	}
+	for _, tag := range tags {
+		if !p.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// orderByParent returns the sorted projects with the children, e.g. worktrees,
+// right after their parent, at any depth. Children of unknown parents are kept
+// in place and projects whose parents form a cycle go last.
+func orderByParent(projects project.Projects) project.Projects {
+	children := map[string]project.Projects{}
+	for _, p := range projects {
+		if p.Parent != "" {
+			if parent, _ := projects.Get(p.Parent); parent != nil {
+				children[parent.Name] = append(children[parent.Name], p)
+			}
+		}
+	}
+
+	ordered := make(project.Projects, 0, len(projects))
+	added := map[string]bool{}
+	var add func(p project.Project)
+	add = func(p project.Project) {
+		if added[p.Name] {
+			return
+		}
+		added[p.Name] = true
+		ordered = append(ordered, p)
+		for _, child := range children[p.Name] {
+			add(child)
+		}
+	}
+	for _, p := range projects {
+		if p.Parent != "" {
+			if parent, _ := projects.Get(p.Parent); parent != nil {
+				continue
+			}
+		}
+		add(p)
+	}
+	for _, p := range projects {
+		add(p)
+	}
+	return ordered
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestOrderByParent(t *testing.T) {
+	t.Parallel()
+
+	projects := project.Projects{
+		{Name: "api"},
+		{Name: "api-feature", Parent: "api"},
+		{Name: "app"},
+		{Name: "orphan", Parent: "missing"},
+		{Name: "zz-api-fix", Parent: "api"},
+		{Name: "api-feature-wip", Parent: "api-feature"},
+		{Name: "loop-a", Parent: "loop-b"},
+		{Name: "loop-b", Parent: "loop-a"},
+	}
+	sort.Sort(projects)
+
+	var names []string
+	for _, p := range orderByParent(projects) {
+		names = append(names, p.Name)
+	}
+	expected := []string{"api", "api-feature", "api-feature-wip", "zz-api-fix", "app", "orphan", "loop-a", "loop-b"}
+	if len(names) != len(expected) {
+		t.Fatalf("unexpected order: %v", names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("unexpected order: %v", names)
+		}
+	}
+}
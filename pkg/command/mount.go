@@ -0,0 +1,260 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/mount"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	mountCmd := &cobra.Command{
+		Use:   "mount [project]",
+		Short: "Mount an SSH project locally with sshfs",
+		Long: `Mount the remote directory of an SSH project with sshfs into a managed
+mountpoint and print it. Without a project the active mounts are listed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: mountProject,
+
+		ValidArgsFunction: completeProjectNames,
+	}
+	unmountCmd := &cobra.Command{
+		Use:     "unmount [project]",
+		Aliases: []string{"umount"},
+		Short:   "Unmount SSH projects mounted with mount",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    unmountProject,
+
+		ValidArgsFunction: completeProjectNames,
+	}
+	unmountCmd.Flags().Bool("all", false, "Unmount every mounted project")
+	rootCmd.AddCommand(mountCmd, unmountCmd)
+}
+
+func mountProject(cmdParam *cobra.Command, params []string) error {
+	if len(params) == 0 {
+		return listMounts()
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	name, pwd := path.SafeName(params...)
+	p, _, err := findProject(projects, name, pwd)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	m, _, err := mountSSHProject(p, false)
+	if err != nil {
+		return err
+	}
+	log.Println(m.Mountpoint)
+	return nil
+}
+
+func listMounts() error {
+	mounts, err := mount.Load()
+	if err != nil {
+		return err
+	}
+	if len(mounts) == 0 {
+		log.Infof("no mounted projects")
+		return nil
+	}
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tSOURCE\tMOUNTPOINT")
+	for _, m := range mounts {
+		name := m.Project
+		if m.Auto {
+			name += " (auto)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, m.Source, m.Mountpoint)
+	}
+	return w.Flush()
+}
+
+func unmountProject(cmdParam *cobra.Command, params []string) error {
+	all := SafeBoolFlag(cmdParam, "all")
+	if all == (len(params) > 0) {
+		return fmt.Errorf("give a project or --all")
+	}
+
+	mounts, err := mount.Load()
+	if err != nil {
+		return err
+	}
+
+	var targets []string
+	if all {
+		for _, m := range mounts {
+			targets = append(targets, m.Project)
+		}
+	} else {
+		name := params[0]
+		if projects, err := project.Load(cfg); err == nil {
+			if p, _, err := projects.Find(name, ""); err == nil {
+				name = p.Name
+			}
+		}
+		if _, ok := mount.Find(mounts, name); !ok {
+			return fmt.Errorf("project '%s' is not mounted", name)
+		}
+		targets = append(targets, name)
+	}
+
+	var failed int
+	for _, name := range targets {
+		if err := unmountTracked(name); err != nil {
+			log.Warnf("%v", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to unmount %d project(s)", failed)
+	}
+	return nil
+}
+
+// mountSSHProject mounts the project with sshfs, reusing its active mount.
+// The bool tells whether the mount was created now. Reusing an automatic
+// mount without auto keeps it, so it outlives the editor that made it.
+func mountSSHProject(p *project.Project, auto bool) (mount.Mount, bool, error) {
+	host, dir, err := p.SSHInfo()
+	if err != nil {
+		return mount.Mount{}, false, fmt.Errorf("only SSH projects can be mounted: %w", err)
+	}
+
+	mounts, err := mount.Load()
+	if err != nil {
+		return mount.Mount{}, false, err
+	}
+	for i := range mounts {
+		if mounts[i].Project != p.Name {
+			continue
+		}
+		if mounts[i].Auto && !auto {
+			mounts[i].Auto = false
+			if err := mount.Save(mounts); err != nil {
+				return mount.Mount{}, false, err
+			}
+		}
+		return mounts[i], false, nil
+	}
+
+	m := mount.Mount{
+		Project:    p.Name,
+		Source:     host + ":" + dir,
+		Mountpoint: mount.Mountpoint(p.Name),
+		Auto:       auto,
+	}
+	for _, other := range mounts {
+		if other.Mountpoint == m.Mountpoint {
+			return mount.Mount{}, false, fmt.Errorf("mountpoint %s is used by project '%s'", m.Mountpoint, other.Project)
+		}
+	}
+	if mounted, err := mount.IsMounted(m.Mountpoint); err != nil {
+		return mount.Mount{}, false, err
+	} else if mounted {
+		return mount.Mount{}, false, fmt.Errorf("something is already mounted on %s, unmount it first", m.Mountpoint)
+	}
+
+	if err := path.EnsureExecutable("sshfs"); err != nil {
+		return mount.Mount{}, false, err
+	}
+	if err := os.MkdirAll(m.Mountpoint, 0o755); err != nil {
+		return mount.Mount{}, false, err
+	}
+
+	cmd := exec.Command("sshfs", sshfsArgs(m.Source, m.Mountpoint, p.SSH.Args())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(m.Mountpoint)
+		return mount.Mount{}, false, fmt.Errorf("failed to mount %s: %w", m.Source, err)
+	}
+	log.Progressf("mounted %s on %s", m.Source, m.Mountpoint)
+
+	if err := mount.Save(append(mounts, m)); err != nil {
+		return m, true, err
+	}
+	return m, true, nil
+}
+
+// sshfsArgs turns the project ssh flags into the -o options sshfs passes to
+// ssh, reconnecting when the connection drops.
+func sshfsArgs(source, mountpoint string, sshArgs []string) []string {
+	args := []string{source, mountpoint, "-o", "reconnect,ServerAliveInterval=15,ServerAliveCountMax=3"}
+	for i := 0; i+1 < len(sshArgs); i += 2 {
+		value := sshArgs[i+1]
+		switch sshArgs[i] {
+		case "-i":
+			value = "IdentityFile=" + value
+		case "-J":
+			value = "ProxyJump=" + value
+		}
+		args = append(args, "-o", value)
+	}
+	return args
+}
+
+// unmountTracked unmounts the project and forgets its mount.
+func unmountTracked(name string) error {
+	return unmountWhen(name, func(mount.Mount) bool { return true })
+}
+
+// unmountAuto unmounts the project only while its mount is automatic, a
+// mount taken over by the mount command stays.
+func unmountAuto(name string) error {
+	return unmountWhen(name, func(m mount.Mount) bool { return m.Auto })
+}
+
+func unmountWhen(name string, match func(mount.Mount) bool) error {
+	mounts, err := mount.Load()
+	if err != nil {
+		return err
+	}
+	m, ok := mount.Find(mounts, name)
+	if !ok || !match(m) {
+		return nil
+	}
+	if err := unmountPath(m.Mountpoint); err != nil {
+		return fmt.Errorf("failed to unmount %s: %w", m.Mountpoint, err)
+	}
+	_ = os.Remove(m.Mountpoint)
+	log.Progressf("unmounted %s", m.Mountpoint)
+	return mount.Save(mount.Remove(mounts, name))
+}
+
+func unmountPath(mountpoint string) error {
+	for _, tool := range []string{"fusermount3", "fusermount"} {
+		if path.ExistsInPathOrAsFile(tool) {
+			return exec.Command(tool, "-u", mountpoint).Run()
+		}
+	}
+	return exec.Command("umount", mountpoint).Run()
+}
+
+// mountedProject returns a local copy of an SSH project pointing at its
+// mount, keeping the workspace file name for workspaces.
+func mountedProject(p *project.Project, mountpoint string) *project.Project {
+	mapped := *p
+	mapped.RootPath = mountpoint
+	if p.IsWorkspace {
+		mapped.RootPath = filepath.Join(mountpoint, p.Path[strings.LastIndex(p.Path, "/")+1:])
+	}
+	mapped.Init()
+	return &mapped
+}
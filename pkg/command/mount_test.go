@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/mount"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestSSHFSArgs(t *testing.T) {
+	opts := &project.SSHOptions{IdentityFile: "/keys/work", JumpHost: "bastion", Options: []string{"Port=2222"}}
+	got := strings.Join(sshfsArgs("devbox:/srv/api", "/mnt/api", opts.Args()), " ")
+	expected := "devbox:/srv/api /mnt/api -o reconnect,ServerAliveInterval=15,ServerAliveCountMax=3 -o IdentityFile=/keys/work -o ProxyJump=bastion -o Port=2222"
+	if got != expected {
+		t.Fatalf("got %q, want %q", got, expected)
+	}
+}
+
+func TestMountedProject(t *testing.T) {
+	p := &project.Project{Name: "api", RootPath: "vscode-remote://ssh-remote+devbox/srv/api"}
+	p.Init()
+	mapped := mountedProject(p, "/mnt/api")
+	if mapped.ProjectType != project.ProjectTypeLocal || mapped.RootPath != "/mnt/api" {
+		t.Fatalf("unexpected project: %s %s", mapped.ProjectType, mapped.RootPath)
+	}
+	if p.ProjectType != project.ProjectTypeSSH {
+		t.Fatal("the original project must not change")
+	}
+
+	ws := &project.Project{Name: "ws", RootPath: "vscode-remote://ssh-remote+devbox/srv/ws.code-workspace"}
+	ws.Init()
+	mapped = mountedProject(ws, "/mnt/ws")
+	if mapped.RootPath != "/mnt/ws/ws.code-workspace" || !mapped.IsWorkspace {
+		t.Fatalf("unexpected workspace: %s %v", mapped.RootPath, mapped.IsWorkspace)
+	}
+}
+
+func TestMountSSHProjectKeepsReusedAutoMount(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	// any mounted directory works, the mount is reused and never unmounted
+	if mounted, err := mount.IsMounted("/proc"); err != nil || !mounted {
+		t.Skip("/proc is not mounted")
+	}
+	if err := mount.Save([]mount.Mount{{Project: "api", Source: "devbox:/srv/api", Mountpoint: "/proc", Auto: true}}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	p := &project.Project{Name: "api", RootPath: "vscode-remote://ssh-remote+devbox/srv/api"}
+	p.Init()
+	m, created, err := mountSSHProject(p, true)
+	if err != nil || created || !m.Auto {
+		t.Fatalf("expected auto mount to be reused as is, got %+v %v %v", m, created, err)
+	}
+
+	m, created, err = mountSSHProject(p, false)
+	if err != nil || created || m.Auto {
+		t.Fatalf("expected explicit mount to take over, got %+v %v %v", m, created, err)
+	}
+	mounts, err := mount.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if saved, ok := mount.Find(mounts, "api"); !ok || saved.Auto {
+		t.Fatalf("expected the mount to be saved as manual, got %+v", mounts)
+	}
+}
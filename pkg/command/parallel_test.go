@@ -0,0 +1,60 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunParallelLimitsJobs(t *testing.T) {
+	var running, maxRunning, calls int32
+	runParallel(context.Background(), 20, 3, false, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	if calls != 20 {
+		t.Fatalf("expected 20 calls, got %d", calls)
+	}
+	if maxRunning > 3 {
+		t.Fatalf("expected at most 3 concurrent calls, got %d", maxRunning)
+	}
+}
+
+func TestRunParallelFailFast(t *testing.T) {
+	var calls int32
+	runParallel(context.Background(), 10, 1, true, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("failed")
+	})
+	if calls != 1 {
+		t.Fatalf("expected fail fast to stop after the first error, got %d calls", calls)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		out bytes.Buffer
+		mu  sync.Mutex
+	)
+	w := newPrefixWriter(&mu, &out, "api | ")
+	w.Write([]byte("first\nsec"))
+	w.Write([]byte("ond\nlast"))
+	w.Flush()
+
+	expected := "api | first\napi | second\napi | last\n"
+	if out.String() != expected {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
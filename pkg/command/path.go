@@ -0,0 +1,66 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "path [project]",
+		Short: "Print the directory of the project",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  projectPath,
+
+		ValidArgsFunction: completeProjectNames,
+	}
+	cmd.Flags().Bool("no-workspace-dir", false, "Print the workspace parent instead of building the workspace shell directory")
+	rootCmd.AddCommand(cmd)
+}
+
+func projectPath(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	name, pwd := path.SafeName(params...)
+	p, _, err := findProject(projects, name, pwd)
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	dir, err := resolveProjectDir(p, SafeBoolFlag(cmdParam, "no-workspace-dir"))
+	if err != nil {
+		return err
+	}
+	log.Println(dir)
+	return nil
+}
+
+// resolveProjectDir returns the local directory a shell should use for p. For
+// workspaces it is the directory built by buildWorkspaceShellDir, which is kept
+// on disk so it can be used after the command exits. WSL projects only have
+// one inside their distro.
+func resolveProjectDir(p *project.Project, noWorkspaceDir bool) (string, error) {
+	if !runsLocally(p) {
+		return "", fmt.Errorf("project type %s has no local directory", p.ProjectType)
+	}
+	p = localWSLProject(p)
+
+	if !p.IsWorkspace {
+		return projectWorkingDir(p), nil
+	}
+	if noWorkspaceDir {
+		return workspaceBaseDir(p), nil
+	}
+	dir, _, err := buildWorkspaceShellDir(p, true)
+	return dir, err
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// currentWSLDistro is the distro this process runs in, empty outside WSL.
+var currentWSLDistro = os.Getenv("WSL_DISTRO_NAME")
+
+// runsLocally reports whether the project directory is reachable from here:
+// local projects and WSL projects opened inside their own distro.
+func runsLocally(p *project.Project) bool {
+	switch p.ProjectType {
+	case project.ProjectTypeLocal:
+		return true
+	case project.ProjectTypeWSL:
+		_, ok := p.WSLLocalPath(currentWSLDistro)
+		return ok
+	}
+	return false
+}
+
+// localWSLProject returns a local copy of a WSL project when running inside
+// its distro, so editors that only take paths can open it.
+func localWSLProject(p *project.Project) *project.Project {
+	local, ok := p.WSLLocalPath(currentWSLDistro)
+	if !ok {
+		return p
+	}
+	mapped := *p
+	mapped.RootPath = local
+	mapped.Init()
+	return &mapped
+}
+
+// isRemote reports whether the project commands go through remoteCommand:
+// SSH, tunnel and container projects and WSL projects outside their distro.
+func isRemote(p *project.Project) bool {
+	switch p.ProjectType {
+	case project.ProjectTypeSSH, project.ProjectTypeTunnel, project.ProjectTypeContainer:
+		return true
+	case project.ProjectTypeWSL:
+		return !runsLocally(p)
+	}
+	return false
+}
+
+// remoteTarget returns the host, tunnel, distro or container name and the
+// directory of a remote project.
+func remoteTarget(p *project.Project) (string, string, error) {
+	switch p.ProjectType {
+	case project.ProjectTypeSSH:
+		return p.SSHInfo()
+	case project.ProjectTypeTunnel:
+		return p.TunnelInfo()
+	case project.ProjectTypeWSL:
+		return p.WSLInfo()
+	case project.ProjectTypeContainer:
+		c, dir, err := p.ContainerInfo()
+		return containerLabel(c), dir, err
+	}
+	return "", "", fmt.Errorf("project type %s is not remote", p.ProjectType)
+}
+
+// remoteCommand runs remoteCmd on a remote project, over ssh, through the
+// tunnel command, with wsl.exe or with docker exec. Extra ssh options only
+// apply to SSH projects.
+func remoteCommand(ctx context.Context, p *project.Project, remoteCmd string, mode sshMode, extra ...string) (*exec.Cmd, error) {
+	switch p.ProjectType {
+	case project.ProjectTypeTunnel:
+		return tunnelCommand(ctx, p, remoteCmd, mode)
+	case project.ProjectTypeContainer:
+		return containerCommand(ctx, p, remoteCmd, mode)
+	case project.ProjectTypeWSL:
+		distro, dir, err := p.WSLInfo()
+		if err != nil {
+			return nil, err
+		}
+		return exec.CommandContext(ctx, "wsl.exe", "-d", distro, "--cd", dir, "--", "sh", "-c", remoteCmd), nil
+	}
+	host, _, err := p.SSHInfo()
+	if err != nil {
+		return nil, err
+	}
+	return sshCommand(ctx, p, host, remoteCmd, mode, extra...)
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestResolveProjectDirOfWSLProject(t *testing.T) {
+	original := currentWSLDistro
+	defer func() { currentWSLDistro = original }()
+
+	p := &project.Project{Name: "api", RootPath: "vscode-remote://wsl+Ubuntu/home/user/api"}
+	p.Init()
+
+	currentWSLDistro = "Ubuntu"
+	if dir, err := resolveProjectDir(p, false); err != nil || dir != "/home/user/api" {
+		t.Fatalf("expected the distro path, got %q %v", dir, err)
+	}
+	currentWSLDistro = "Debian"
+	if dir, err := resolveProjectDir(p, false); err == nil {
+		t.Fatalf("expected no local directory outside the distro, got %q", dir)
+	}
+}
+
+func TestWSLProjectsRunLocallyInsideTheirDistro(t *testing.T) {
+	original := currentWSLDistro
+	defer func() { currentWSLDistro = original }()
+
+	p := &project.Project{Name: "api", RootPath: "vscode-remote://wsl+Ubuntu/home/user/api"}
+	p.Init()
+
+	currentWSLDistro = "Ubuntu"
+	if !runsLocally(p) || isRemote(p) {
+		t.Fatal("expected project to run locally inside its distro")
+	}
+	if dir := projectWorkingDir(p); dir != "/home/user/api" {
+		t.Fatalf("unexpected working dir: %s", dir)
+	}
+
+	for _, current := range []string{"Debian", ""} {
+		currentWSLDistro = current
+		if runsLocally(p) || !isRemote(p) {
+			t.Fatalf("expected project to be remote from %q", current)
+		}
+	}
+
+	currentWSLDistro = "Ubuntu"
+	mapped := localWSLProject(p)
+	if mapped.ProjectType != project.ProjectTypeLocal || mapped.RootPath != "/home/user/api" {
+		t.Fatalf("unexpected mapping: %s %s", mapped.ProjectType, mapped.RootPath)
+	}
+	if p.ProjectType != project.ProjectTypeWSL {
+		t.Fatal("the original project must not change")
+	}
+	currentWSLDistro = "Debian"
+	if other := localWSLProject(p); other != p {
+		t.Fatal("expected projects of other distros to be kept")
+	}
+
+	cmd, err := remoteCommand(context.Background(), p, "ls", sshTerminal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "wsl.exe -d Ubuntu --cd /home/user/api -- sh -c ls"
+	if got := strings.Join(cmd.Args, " "); got != expected {
+		t.Fatalf("got %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "rename <project> <new-name>",
+		Short: "Rename a project keeping sessions consistent",
+		Args:  cobra.ExactArgs(2),
+		RunE:  rename,
+
+		ValidArgsFunction: completeProjectNames,
+	}
+	cmd.Flags().Bool("keep-alias", false, "Keep the old name as alias of the project")
+	rootCmd.AddCommand(cmd)
+}
+
+func rename(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	current, _, err := projects.Lookup(params[0])
+	if err != nil {
+		if err == project.ErrProjectNotFound {
+			return fmt.Errorf("project '%s' not found", params[0])
+		}
+		return err
+	}
+	oldName := current.Name
+
+	p, err := projects.Rename(oldName, params[1], SafeBoolFlag(cmdParam, "keep-alias"))
+	if err != nil {
+		return err
+	}
+	if err := projects.Save(cfg); err != nil {
+		return err
+	}
+	log.Infof("Project '%s' renamed to '%s'", oldName, p.Name)
+
+	renameSessions(sanitizeSessionName(oldName), sanitizeSessionName(p.Name))
+	return nil
+}
+
+func renameSessions(from, to string) {
+	if from == to {
+		return
+	}
+	for _, backend := range availableSessionBackends {
+		renamer, ok := backend.(sessionRenamer)
+		if !ok {
+			continue
+		}
+		renamed, err := renamer.RenameSession(from, to)
+		if err != nil {
+			log.Warnf("%v", err)
+			continue
+		}
+		if renamed {
+			log.Infof("%s session '%s' renamed to '%s'", backend.Name(), from, to)
+		}
+	}
+}
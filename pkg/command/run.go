@@ -0,0 +1,171 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/filipenos/projects/pkg/task"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	runCmd := &cobra.Command{
+		Use:                "run <project> <task> [args...]",
+		Short:              "Run a named task of the project",
+		DisableFlagParsing: true,
+		RunE:               runTask,
+		ValidArgsFunction:  completeTasks,
+	}
+	tasksCmd := &cobra.Command{
+		Use:               "tasks <project>",
+		Short:             "List the tasks of the project",
+		Args:              cobra.ExactArgs(1),
+		RunE:              listTasks,
+		ValidArgsFunction: completeProjectNames,
+	}
+	rootCmd.AddCommand(runCmd, tasksCmd)
+}
+
+func runTask(cmdParam *cobra.Command, params []string) error {
+	if len(params) < 2 {
+		return fmt.Errorf("usage: projects run <project> <task> [args...]")
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	tasks, err := projectTasks(p)
+	if err != nil {
+		return err
+	}
+	t, ok := task.Find(tasks, params[1])
+	if !ok {
+		return fmt.Errorf("task '%s' not found in project '%s'", params[1], p.Name)
+	}
+
+	env, err := projectEnvironment(p, t.Env)
+	if err != nil {
+		return err
+	}
+
+	command := t.Command
+	for _, arg := range params[2:] {
+		command += " " + shellQuote(arg)
+	}
+
+	var cmd *exec.Cmd
+	switch {
+	case runsLocally(p):
+		log.Infof("run %s: %s", t.Name, command)
+		cmd = exec.Command("sh", "-c", command)
+		cmd.Dir = filepath.Join(projectWorkingDir(p), t.Dir)
+		cmd.Env = append(os.Environ(), env...)
+
+	case isRemote(p):
+		_, sshPath, err := remoteTarget(p)
+		if err != nil {
+			return err
+		}
+		if t.Dir != "" {
+			sshPath = sshPath + "/" + t.Dir
+		}
+		remoteCmd := fmt.Sprintf("cd %s && ", shellQuote(sshPath))
+		if len(env) > 0 {
+			remoteCmd += exportCommand(env) + " && "
+		}
+		log.Infof("run %s on %s host: %s", t.Name, p.ProjectType, command)
+		cmd, err = remoteCommand(context.Background(), p, remoteCmd+command, sshTerminal)
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("project type %s not supported for run command", p.ProjectType)
+	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func listTasks(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return err
+	}
+
+	tasks, err := projectTasks(p)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		log.Infof("no tasks found for project '%s'", p.Name)
+		return nil
+	}
+	for _, t := range tasks {
+		log.Printf("%-20s %-30s (%s)\n", t.Name, t.Command, t.Source)
+	}
+	return nil
+}
+
+// projectTasks returns the tasks declared on the project merged with the ones
+// discovered from its task runners. Discovery only works for local projects.
+func projectTasks(p *project.Project) ([]task.Task, error) {
+	var discovered []task.Task
+	if p.ProjectType == project.ProjectTypeLocal {
+		var err error
+		discovered, err = task.Discover(projectWorkingDir(p))
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover tasks: %w", err)
+		}
+	}
+	return task.Merge(p.Tasks, discovered), nil
+}
+
+func completeTasks(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeProjectNames(cmd, args, toComplete)
+	case 1:
+		projects, err := project.Load(cfg)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		p, _, err := projects.Find(args[0], "")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tasks, err := projectTasks(p)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var completions []string
+		for _, t := range tasks {
+			if strings.HasPrefix(t.Name, toComplete) {
+				completions = append(completions, t.Name+"\t"+t.Command)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveDefault
+}
@@ -3,10 +3,10 @@ package command
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/filipenos/projects/pkg/git"
 	"github.com/filipenos/projects/pkg/log"
 	"github.com/filipenos/projects/pkg/project"
 	"github.com/spf13/cobra"
@@ -75,13 +75,8 @@ func scan(cmdParam *cobra.Command, params []string) error {
 			Enabled:  true,
 		}
 
-		gitDir := filepath.Join(fullPath, ".git")
-		if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
-			cmd := exec.Command("git", "-C", fullPath, "remote", "get-url", "origin")
-			out, _ := cmd.CombinedOutput()
-			if scm := strings.TrimSpace(string(out)); scm != "" {
-				p.SCM = scm
-			}
+		if git.IsRepo(fullPath) {
+			p.SCM = git.RemoteURL(fullPath)
 		}
 
 		projects = append(projects, p)
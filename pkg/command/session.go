@@ -16,9 +16,27 @@ type sessionBackend interface {
 	Run(p *project.Project, args []string) error
 }
 
+// sessionRenamer is implemented by backends able to rename a live session.
+type sessionRenamer interface {
+	RenameSession(from, to string) (bool, error)
+}
+
+// liveSession is a session reported by a backend.
+type liveSession struct {
+	Name     string
+	Attached bool
+}
+
+// sessionManager is implemented by backends able to list and kill sessions.
+type sessionManager interface {
+	ListSessions() ([]liveSession, error)
+	KillSession(name string) error
+}
+
 var availableSessionBackends = []sessionBackend{
 	newTmuxBackend(),
 	newScreenBackend(),
+	newZellijBackend(),
 }
 
 func init() {
@@ -27,8 +45,10 @@ func init() {
 		Short:              "Manage terminal sessions for your project",
 		DisableFlagParsing: true,
 		RunE:               runSession,
+		ValidArgsFunction:  completeSession,
 	}
 	sessionCmd.Aliases = collectSessionAliases()
+	sessionCmd.AddCommand(newSessionSubcommands()...)
 
 	rootCmd.AddCommand(sessionCmd)
 }
@@ -38,12 +58,7 @@ func runSession(cmdParam *cobra.Command, params []string) error {
 		return fmt.Errorf("project name is required")
 	}
 
-	defaultBackend := cfg.SessionBackend
-	if defaultBackend == "" {
-		defaultBackend = "tmux"
-	}
-
-	backendName, projectName, backendArgs, err := parseSessionParams(params, defaultBackend)
+	backendName, projectName, backendArgs, err := parseSessionParams(params, defaultSessionBackend())
 	if err != nil {
 		return err
 	}
@@ -62,9 +77,9 @@ func runSession(cmdParam *cobra.Command, params []string) error {
 		return err
 	}
 
-	p, _ := projects.Find(projectName, "")
-	if p == nil {
-		return fmt.Errorf("project not found")
+	p, _, err := findProject(projects, projectName, "")
+	if err != nil {
+		return err
 	}
 	if err := p.Validate(); err != nil {
 		return err
@@ -74,6 +89,36 @@ func runSession(cmdParam *cobra.Command, params []string) error {
 	return backend.Run(p, backendArgs)
 }
 
+// completeSession completes --backend values and the project name. Flags are
+// parsed by hand because the command disables cobra flag parsing.
+func completeSession(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if strings.HasPrefix(toComplete, "--backend=") {
+		var values []string
+		for _, alias := range collectSessionAliases() {
+			values = append(values, "--backend="+alias)
+		}
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+	if n := len(args); n > 0 && (args[n-1] == "--backend" || args[n-1] == "-b") {
+		return collectSessionAliases(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	positional := 0
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--backend" || args[i] == "-b":
+			i++
+		case strings.HasPrefix(args[i], "--backend="):
+		default:
+			positional++
+		}
+	}
+	if positional > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completeProjectNames(cmd, nil, toComplete)
+}
+
 func parseSessionParams(params []string, defaultBackend string) (backend, project string, backendArgs []string, err error) {
 	backend = defaultBackend
 	clean := make([]string, 0, len(params))
@@ -112,6 +157,14 @@ func parseSessionParams(params []string, defaultBackend string) (backend, projec
 	return backend, project, backendArgs, nil
 }
 
+// defaultSessionBackend returns the configured session backend, tmux when unset.
+func defaultSessionBackend() string {
+	if cfg.SessionBackend == "" {
+		return "tmux"
+	}
+	return cfg.SessionBackend
+}
+
 func getSessionBackend(name string) (sessionBackend, error) {
 	name = strings.ToLower(strings.TrimSpace(name))
 	for _, backend := range availableSessionBackends {
@@ -0,0 +1,183 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func newSessionSubcommands() []*cobra.Command {
+	lsCmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List live sessions and their projects",
+		Args:    cobra.NoArgs,
+		RunE:    sessionList,
+	}
+
+	killCmd := &cobra.Command{
+		Use:               "kill <project>",
+		Short:             "Kill the sessions of the project",
+		Args:              cobra.ExactArgs(1),
+		RunE:              sessionKill,
+		ValidArgsFunction: completeProjectNames,
+	}
+	killCmd.Flags().StringP("backend", "b", "", "Kill only the session of this backend")
+	killCmd.RegisterFlagCompletionFunc("backend", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return collectSessionAliases(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	switchCmd := &cobra.Command{
+		Use:               "switch <project>",
+		Short:             "Switch the tmux client to the project session, creating it if needed",
+		Args:              cobra.ExactArgs(1),
+		RunE:              sessionSwitch,
+		ValidArgsFunction: completeProjectNames,
+	}
+
+	return []*cobra.Command{lsCmd, killCmd, switchCmd}
+}
+
+// projectSession is a live session with the project it belongs to, if any.
+type projectSession struct {
+	Backend string
+	Project string
+	liveSession
+}
+
+// listProjectSessions returns the live sessions of every backend, mapped to
+// projects through sanitizeSessionName. Backends that fail are reported and
+// skipped.
+func listProjectSessions(projects project.Projects, backends []sessionBackend) []projectSession {
+	byName := map[string]string{}
+	for _, p := range projects {
+		byName[sanitizeSessionName(p.Name)] = p.Name
+	}
+
+	var sessions []projectSession
+	for _, backend := range backends {
+		manager, ok := backend.(sessionManager)
+		if !ok {
+			continue
+		}
+		live, err := manager.ListSessions()
+		if err != nil {
+			log.Warnf("%v", err)
+			continue
+		}
+		for _, s := range live {
+			sessions = append(sessions, projectSession{Backend: backend.Name(), Project: byName[s.Name], liveSession: s})
+		}
+	}
+	return sessions
+}
+
+// markAttached sets Attached on projects with a live session of the
+// configured backend, so list does not probe every multiplexer.
+func markAttached(projects project.Projects) {
+	backend, err := getSessionBackend(defaultSessionBackend())
+	if err != nil {
+		log.Warnf("%v", err)
+		return
+	}
+	live := map[string]bool{}
+	for _, s := range listProjectSessions(projects, []sessionBackend{backend}) {
+		if s.Project != "" {
+			live[s.Project] = true
+		}
+	}
+	for i := range projects {
+		projects[i].Attached = live[projects[i].Name]
+	}
+}
+
+func sessionList(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	sessions := listProjectSessions(projects, availableSessionBackends)
+	if len(sessions) == 0 {
+		log.Infof("no live sessions")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BACKEND\tSESSION\tPROJECT\tATTACHED")
+	for _, s := range sessions {
+		name := s.Project
+		if name == "" {
+			name = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", s.Backend, s.Name, name, s.Attached)
+	}
+	return w.Flush()
+}
+
+func sessionKill(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return err
+	}
+
+	backendName := SafeStringFlag(cmdParam, "backend")
+	if backendName != "" {
+		if _, err := getSessionBackend(backendName); err != nil {
+			return err
+		}
+	}
+
+	sessionName := sanitizeSessionName(p.Name)
+	killed := 0
+	for _, s := range listProjectSessions(projects, availableSessionBackends) {
+		if s.Name != sessionName {
+			continue
+		}
+		backend, err := getSessionBackend(s.Backend)
+		if err != nil || backendName != "" && !backendMatches(backend, backendName) {
+			continue
+		}
+		if err := backend.(sessionManager).KillSession(s.Name); err != nil {
+			return fmt.Errorf("failed to kill %s session '%s': %w", s.Backend, s.Name, err)
+		}
+		log.Infof("%s session '%s' killed", s.Backend, s.Name)
+		killed++
+	}
+	if killed == 0 {
+		return fmt.Errorf("no live session for project '%s'", p.Name)
+	}
+	return nil
+}
+
+func sessionSwitch(cmdParam *cobra.Command, params []string) error {
+	if os.Getenv("TMUX") == "" {
+		log.Infof("not inside tmux, attaching instead of switching")
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	p, _, err := findProject(projects, params[0], "")
+	if err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	backend, err := getSessionBackend("tmux")
+	if err != nil {
+		return err
+	}
+	return backend.Run(p, nil)
+}
@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// remoteSession describes how a backend checks and opens a session on the
+// SSH host of a project.
+type remoteSession struct {
+	backend string
+	// check exits 0 when the session exists and 1 when it doesn't
+	check func(name string) []string
+	// open creates the session or attaches to it when it already exists
+	open func(name, dir string, args []string) []string
+}
+
+var remoteTmuxSession = remoteSession{
+	backend: "tmux",
+	check: func(name string) []string {
+		// "=" avoids tmux matching another session by prefix
+		return []string{"tmux", "has-session", "-t", "=" + name}
+	},
+	open: func(name, dir string, args []string) []string {
+		return append([]string{"tmux", "new-session", "-A", "-s", name, "-c", dir}, args...)
+	},
+}
+
+var remoteScreenSession = remoteSession{
+	backend: "screen",
+	check: func(name string) []string {
+		return []string{"screen", "-S", name, "-Q", "select", "."}
+	},
+	open: func(name, dir string, args []string) []string {
+		return append([]string{"screen", "-S", name, "-d", "-RR"}, args...)
+	},
+}
+
+func runRemoteSession(p *project.Project, rs remoteSession, backendArgs []string) error {
+	host, dir, err := remoteTarget(p)
+	if err != nil {
+		return err
+	}
+
+	sessionName := sanitizeSessionName(p.Name)
+
+	sessionExists, err := remoteSessionExists(p, host, rs, sessionName)
+	if err != nil {
+		return err
+	}
+
+	if sessionExists && len(backendArgs) > 0 {
+		return fmt.Errorf("%s session '%s' already exists on %s; close it before executing a new command", rs.backend, sessionName, host)
+	}
+	if len(p.Windows) > 0 {
+		log.Warnf("session layouts are only built for local projects")
+	}
+
+	env, err := p.ShellEnvironmentWith(nil)
+	if err != nil {
+		return err
+	}
+
+	open := rs.open(sessionName, dir, backendArgs)
+	log.Infof("%s on %s", strings.Join(open, " "), host)
+
+	cmd, err := remoteCommand(context.Background(), p, remoteSessionCommand(dir, env, open), sshInteractive, workspaceForwards(p, host)...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// remoteSessionCommand builds the remote shell command opening the session,
+// the environment only applies when the session is created.
+func remoteSessionCommand(dir string, env []string, open []string) string {
+	var b strings.Builder
+	b.WriteString("cd " + shellQuote(dir) + " && ")
+	if len(env) > 0 {
+		b.WriteString(exportCommand(env) + " && ")
+	}
+	b.WriteString("exec " + quoteArgs(open))
+	return b.String()
+}
+
+// remoteSessionExists checks the backend and the session in a single
+// connection, exiting 127 when the backend is not installed.
+func remoteSessionExists(p *project.Project, host string, rs remoteSession, name string) (bool, error) {
+	script := fmt.Sprintf("command -v %s >/dev/null 2>&1 || exit 127; %s >/dev/null 2>&1",
+		rs.backend, quoteArgs(rs.check(name)))
+	cmd, err := remoteCommand(context.Background(), p, script, sshBatch)
+	if err != nil {
+		return false, err
+	}
+	return remoteCheckResult(host, rs.backend, cmd.Run())
+}
+
+func remoteCheckResult(host, backend string, err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return false, err
+	}
+	switch exitErr.ExitCode() {
+	case 1:
+		return false, nil
+	case 127:
+		return false, fmt.Errorf("%s is not installed on %s", backend, host)
+	case 255:
+		return false, fmt.Errorf("failed to connect to %s", host)
+	}
+	return false, fmt.Errorf("failed to check %s session on %s: %w", backend, host, err)
+}
+
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
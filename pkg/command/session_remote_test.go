@@ -0,0 +1,42 @@
+package command
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRemoteSessionCommand(t *testing.T) {
+	open := remoteTmuxSession.open("api", "/srv/my api", []string{"htop"})
+	got := remoteSessionCommand("/srv/my api", []string{`A='it'\''s'`}, open)
+	expected := `cd '/srv/my api' && export A='it'\''s' && exec 'tmux' 'new-session' '-A' '-s' 'api' '-c' '/srv/my api' 'htop'`
+	if got != expected {
+		t.Fatalf("unexpected command:\n%s", got)
+	}
+
+	open = remoteScreenSession.open("api", "/srv/api", nil)
+	got = remoteSessionCommand("/srv/api", nil, open)
+	expected = `cd '/srv/api' && exec 'screen' '-S' 'api' '-d' '-RR'`
+	if got != expected {
+		t.Fatalf("unexpected command:\n%s", got)
+	}
+}
+
+func TestRemoteCheckResult(t *testing.T) {
+	exit := func(code string) error {
+		return exec.Command("sh", "-c", "exit "+code).Run()
+	}
+
+	if exists, err := remoteCheckResult("host", "tmux", nil); !exists || err != nil {
+		t.Fatalf("expected existing session, got %v %v", exists, err)
+	}
+	if exists, err := remoteCheckResult("host", "tmux", exit("1")); exists || err != nil {
+		t.Fatalf("expected missing session, got %v %v", exists, err)
+	}
+	if _, err := remoteCheckResult("host", "tmux", exit("127")); err == nil || !strings.Contains(err.Error(), "tmux is not installed on host") {
+		t.Fatalf("expected missing backend error, got %v", err)
+	}
+	if _, err := remoteCheckResult("host", "tmux", exit("255")); err == nil || !strings.Contains(err.Error(), "failed to connect to host") {
+		t.Fatalf("expected connection error, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 
 	"github.com/filipenos/projects/pkg/log"
@@ -26,8 +27,10 @@ func (b *screenBackend) Aliases() []string {
 }
 
 func (b *screenBackend) Run(p *project.Project, backendArgs []string) error {
-	switch p.ProjectType {
-	case project.ProjectTypeLocal, project.ProjectTypeWSL:
+	switch {
+	case runsLocally(p):
+	case isRemote(p):
+		return runRemoteSession(p, remoteScreenSession, backendArgs)
 	default:
 		return fmt.Errorf("project type %s not supported for screen", p.ProjectType)
 	}
@@ -48,6 +51,11 @@ func (b *screenBackend) Run(p *project.Project, backendArgs []string) error {
 		return fmt.Errorf("screen session '%s' already exists; close it before executing a new command", sessionName)
 	}
 
+	env, err := p.Environment()
+	if err != nil {
+		return err
+	}
+
 	args := []string{"-S", sessionName, "-d", "-RR"}
 	args = append(args, backendArgs...)
 
@@ -55,6 +63,8 @@ func (b *screenBackend) Run(p *project.Project, backendArgs []string) error {
 
 	cmd := exec.Command("screen", args...)
 	cmd.Dir = workingDir
+	// screen only applies the environment when it creates the session
+	cmd.Env = append(os.Environ(), env...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -73,3 +83,44 @@ func screenSessionExists(session string) (bool, error) {
 	}
 	return true, nil
 }
+
+func (b *screenBackend) RenameSession(from, to string) (bool, error) {
+	if !path.ExistsInPathOrAsFile("screen") {
+		return false, nil
+	}
+	exists, err := screenSessionExists(from)
+	if err != nil || !exists {
+		return false, err
+	}
+	if err := exec.Command("screen", "-S", from, "-X", "sessionname", to).Run(); err != nil {
+		return false, fmt.Errorf("failed to rename screen session '%s': %w", from, err)
+	}
+	return true, nil
+}
+
+func (b *screenBackend) ListSessions() ([]liveSession, error) {
+	if !path.ExistsInPathOrAsFile("screen") {
+		return nil, nil
+	}
+	// screen -ls exits with 1 in most versions even when listing sessions
+	out, _ := exec.Command("screen", "-ls").CombinedOutput()
+	return parseScreenSessions(string(out)), nil
+}
+
+var screenSessionRe = regexp.MustCompile(`^\s+\d+\.(\S+)\s.*\((Attached|Detached|Multi, attached|Multi, detached)\)`)
+
+func parseScreenSessions(out string) []liveSession {
+	var sessions []liveSession
+	for _, line := range strings.Split(out, "\n") {
+		m := screenSessionRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		sessions = append(sessions, liveSession{Name: m[1], Attached: m[2] == "Attached" || m[2] == "Multi, attached"})
+	}
+	return sessions
+}
+
+func (b *screenBackend) KillSession(name string) error {
+	return exec.Command("screen", "-S", name, "-X", "quit").Run()
+}
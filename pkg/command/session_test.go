@@ -74,3 +74,35 @@ func TestGetSessionBackend(t *testing.T) {
 		t.Fatalf("expected error for unknown backend")
 	}
 }
+
+type fakeManagedBackend struct {
+	fakeSessionBackend
+	sessions []liveSession
+}
+
+func (f *fakeManagedBackend) ListSessions() ([]liveSession, error) { return f.sessions, nil }
+func (f *fakeManagedBackend) KillSession(string) error             { return nil }
+
+func TestListProjectSessions(t *testing.T) {
+	original, originalBackend := availableSessionBackends, cfg.SessionBackend
+	defer func() { availableSessionBackends, cfg.SessionBackend = original, originalBackend }()
+
+	availableSessionBackends = []sessionBackend{
+		&fakeSessionBackend{name: "demo"},
+		&fakeManagedBackend{fakeSessionBackend{name: "managed"}, []liveSession{{Name: "my-api", Attached: true}, {Name: "other"}}},
+		&fakeManagedBackend{fakeSessionBackend{name: "other"}, []liveSession{{Name: "web"}}},
+	}
+
+	projects := project.Projects{{Name: "my api"}, {Name: "web"}}
+	sessions := listProjectSessions(projects, availableSessionBackends)
+	if len(sessions) != 3 || sessions[0].Project != "my api" || sessions[1].Project != "" || sessions[2].Project != "web" {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+
+	// only the configured backend is checked by list
+	cfg.SessionBackend = "managed"
+	markAttached(projects)
+	if !projects[0].Attached || projects[1].Attached {
+		t.Fatalf("unexpected attached flags: %+v", projects)
+	}
+}
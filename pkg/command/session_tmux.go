@@ -26,8 +26,10 @@ func (b *tmuxBackend) Aliases() []string {
 }
 
 func (b *tmuxBackend) Run(p *project.Project, backendArgs []string) error {
-	switch p.ProjectType {
-	case project.ProjectTypeLocal, project.ProjectTypeWSL:
+	switch {
+	case runsLocally(p):
+	case isRemote(p):
+		return runRemoteSession(p, remoteTmuxSession, backendArgs)
 	default:
 		return fmt.Errorf("project type %s not supported for tmux", p.ProjectType)
 	}
@@ -48,16 +50,47 @@ func (b *tmuxBackend) Run(p *project.Project, backendArgs []string) error {
 		return fmt.Errorf("tmux session '%s' already exists; close it before executing a new command", sessionName)
 	}
 
-	var args []string
-	if sessionExists {
-		args = []string{"attach-session", "-d", "-t", sessionName}
-	} else {
-		args = []string{"new-session", "-s", sessionName, "-c", workingDir}
+	// inside tmux the session is created detached and the client switched to
+	// it, attaching would nest tmux
+	insideTmux := os.Getenv("TMUX") != ""
+
+	if !sessionExists {
+		if len(p.Windows) > 0 && len(backendArgs) == 0 {
+			env, err := p.Environment()
+			if err != nil {
+				return err
+			}
+			log.Infof("building tmux layout with %d window(s)", len(p.Windows))
+			if err := buildTmuxLayout(sessionName, workingDir, p.Windows, env); err != nil {
+				return err
+			}
+		} else {
+			env, err := p.Environment()
+			if err != nil {
+				return err
+			}
+			args := []string{"new-session", "-s", sessionName, "-c", workingDir}
+			if insideTmux {
+				args = append(args, "-d")
+			}
+			for _, kv := range env {
+				args = append(args, "-e", kv)
+			}
+			args = append(args, backendArgs...)
+			if err := runTmux(args); err != nil || !insideTmux {
+				return err
+			}
+		}
 	}
 
-	args = append(args, backendArgs...)
+	if insideTmux {
+		return runTmux([]string{"switch-client", "-t", sessionName})
+	}
+	return runTmux([]string{"attach-session", "-d", "-t", sessionName})
+}
 
-	log.Infof("tmux %s", strings.Join(args, " "))
+func runTmux(args []string) error {
+	log.Infof("tmux %s", strings.Join(maskEnvArgs(args), " "))
 
 	cmd := exec.Command("tmux", args...)
 	cmd.Stdin = os.Stdin
@@ -78,3 +111,48 @@ func tmuxSessionExists(session string) (bool, error) {
 	}
 	return true, nil
 }
+
+func (b *tmuxBackend) RenameSession(from, to string) (bool, error) {
+	if !path.ExistsInPathOrAsFile("tmux") {
+		return false, nil
+	}
+	exists, err := tmuxSessionExists(from)
+	if err != nil || !exists {
+		return false, err
+	}
+	if err := exec.Command("tmux", "rename-session", "-t", from, to).Run(); err != nil {
+		return false, fmt.Errorf("failed to rename tmux session '%s': %w", from, err)
+	}
+	return true, nil
+}
+
+func (b *tmuxBackend) ListSessions() ([]liveSession, error) {
+	if !path.ExistsInPathOrAsFile("tmux") {
+		return nil, nil
+	}
+	out, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name} #{session_attached}").CombinedOutput()
+	if err != nil {
+		// tmux fails when the server is not running, meaning no sessions
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list tmux sessions: %w", err)
+	}
+	return parseTmuxSessions(string(out)), nil
+}
+
+func parseTmuxSessions(out string) []liveSession {
+	var sessions []liveSession
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		i := strings.LastIndex(line, " ")
+		if i == -1 {
+			continue
+		}
+		sessions = append(sessions, liveSession{Name: line[:i], Attached: line[i+1:] != "0"})
+	}
+	return sessions
+}
+
+func (b *tmuxBackend) KillSession(name string) error {
+	return exec.Command("tmux", "kill-session", "-t", name).Run()
+}
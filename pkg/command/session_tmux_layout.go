@@ -0,0 +1,107 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// tmuxCommand runs tmux returning its trimmed output; replaced in tests.
+var tmuxCommand = func(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("tmux", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("tmux %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("tmux %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// buildTmuxLayout creates a detached session with the windows and panes of
+// the project, tmuxinator style. Commands are typed into the panes so the
+// shell stays open when they exit. A session left half built by an error is
+// killed so the next attempt starts from scratch.
+func buildTmuxLayout(sessionName, workingDir string, windows []project.Window, env []string) (err error) {
+	var firstWindow string
+	defer func() {
+		if err != nil && firstWindow != "" {
+			tmuxCommand("kill-session", "-t", sessionName)
+		}
+	}()
+	for i, w := range windows {
+		args := []string{"new-window", "-d", "-P", "-F", "#{window_id}", "-t", sessionName + ":"}
+		if i == 0 {
+			args = []string{"new-session", "-d", "-P", "-F", "#{window_id}", "-s", sessionName}
+			for _, kv := range env {
+				args = append(args, "-e", kv)
+			}
+		}
+		args = append(args, "-c", layoutDir(workingDir, w.Dir))
+		if w.Name != "" {
+			args = append(args, "-n", w.Name)
+		}
+
+		windowID, err := tmuxCommand(args...)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			firstWindow = windowID
+		}
+		if err := tmuxSendCommand(windowID, w.Command); err != nil {
+			return err
+		}
+
+		for _, pane := range w.Panes {
+			paneID, err := tmuxCommand("split-window", "-d", "-P", "-F", "#{pane_id}", "-t", windowID, "-c", layoutDir(workingDir, pane.Dir))
+			if err != nil {
+				return err
+			}
+			if err := tmuxSendCommand(paneID, pane.Command); err != nil {
+				return err
+			}
+		}
+
+		if w.Layout != "" {
+			if _, err := tmuxCommand("select-layout", "-t", windowID, w.Layout); err != nil {
+				return err
+			}
+		}
+	}
+
+	if firstWindow != "" {
+		if _, err := tmuxCommand("select-window", "-t", firstWindow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tmuxSendCommand(target, command string) error {
+	if command == "" {
+		return nil
+	}
+	if _, err := tmuxCommand("send-keys", "-t", target, "-l", command); err != nil {
+		return err
+	}
+	_, err := tmuxCommand("send-keys", "-t", target, "Enter")
+	return err
+}
+
+func layoutDir(base, dir string) string {
+	if dir == "" {
+		return base
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(base, dir)
+}
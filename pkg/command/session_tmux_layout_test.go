@@ -0,0 +1,94 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestBuildTmuxLayout(t *testing.T) {
+	original := tmuxCommand
+	defer func() { tmuxCommand = original }()
+
+	var (
+		calls []string
+		ids   int
+	)
+	tmuxCommand = func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		ids++
+		return fmt.Sprintf("@%d", ids), nil
+	}
+
+	windows := []project.Window{
+		{Name: "editor", Command: "nvim"},
+		{
+			Name:   "servers",
+			Dir:    "backend",
+			Layout: "even-horizontal",
+			Panes: []project.Pane{
+				{Dir: "/var/log", Command: "tail -f app.log"},
+			},
+		},
+	}
+	if err := buildTmuxLayout("proj", "/src/proj", windows, []string{"A=1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"new-session -d -P -F #{window_id} -s proj -e A=1 -c /src/proj -n editor",
+		"send-keys -t @1 -l nvim",
+		"send-keys -t @1 Enter",
+		"new-window -d -P -F #{window_id} -t proj: -c /src/proj/backend -n servers",
+		"split-window -d -P -F #{pane_id} -t @4 -c /var/log",
+		"send-keys -t @5 -l tail -f app.log",
+		"send-keys -t @5 Enter",
+		"select-layout -t @4 even-horizontal",
+		"select-window -t @1",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected tmux calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestBuildTmuxLayoutStopsOnError(t *testing.T) {
+	original := tmuxCommand
+	defer func() { tmuxCommand = original }()
+
+	calls := 0
+	tmuxCommand = func(args ...string) (string, error) {
+		calls++
+		return "", fmt.Errorf("tmux failed")
+	}
+
+	if err := buildTmuxLayout("proj", "/src", []project.Window{{Name: "a"}, {Name: "b"}}, nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected layout to stop on first error, got %d calls", calls)
+	}
+}
+
+func TestBuildTmuxLayoutKillsPartialSession(t *testing.T) {
+	original := tmuxCommand
+	defer func() { tmuxCommand = original }()
+
+	var calls []string
+	tmuxCommand = func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		if args[0] == "split-window" {
+			return "", fmt.Errorf("tmux failed")
+		}
+		return "@1", nil
+	}
+
+	windows := []project.Window{{Name: "a", Panes: []project.Pane{{Command: "ls"}}}}
+	if err := buildTmuxLayout("proj", "/src", windows, nil); err == nil {
+		t.Fatalf("expected error")
+	}
+	if last := calls[len(calls)-1]; last != "kill-session -t proj" {
+		t.Fatalf("expected partial session to be killed, last call was %q", last)
+	}
+}
@@ -43,3 +43,19 @@ func sanitizeSessionName(name string) string {
 	}
 	return b.String()
 }
+
+// maskEnvArgs hides the values of "-e KEY=VALUE" arguments so the project
+// environment is not printed in logs.
+func maskEnvArgs(args []string) []string {
+	masked := make([]string, len(args))
+	copy(masked, args)
+	for i := 1; i < len(masked); i++ {
+		if masked[i-1] != "-e" {
+			continue
+		}
+		if key, _, ok := strings.Cut(masked[i], "="); ok {
+			masked[i] = key + "=***"
+		}
+	}
+	return masked
+}
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+type zellijBackend struct{}
+
+func newZellijBackend() sessionBackend {
+	return &zellijBackend{}
+}
+
+func (b *zellijBackend) Name() string {
+	return "zellij"
+}
+
+func (b *zellijBackend) Aliases() []string {
+	return nil
+}
+
+// Run creates or attaches the zellij session of the project. backendArgs are
+// passed to the zellij CLI after --session and --layout, e.g. "options
+// --default-shell fish", so they only apply when the session is created.
+func (b *zellijBackend) Run(p *project.Project, backendArgs []string) error {
+	switch {
+	case runsLocally(p):
+	default:
+		return fmt.Errorf("project type %s not supported for zellij", p.ProjectType)
+	}
+
+	if err := path.EnsureExecutable("zellij"); err != nil {
+		return err
+	}
+	if os.Getenv("ZELLIJ") != "" {
+		return fmt.Errorf("already inside a zellij session; detach before opening another one")
+	}
+
+	sessionName := sanitizeSessionName(p.Name)
+	workingDir := projectWorkingDir(p)
+
+	sessionExists, err := zellijSessionExists(sessionName)
+	if err != nil {
+		return err
+	}
+
+	if sessionExists && len(backendArgs) > 0 {
+		return fmt.Errorf("zellij session '%s' already exists; close it before executing a new command", sessionName)
+	}
+
+	env, err := p.Environment()
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	if sessionExists {
+		args = []string{"attach", sessionName}
+	} else {
+		args = []string{"--session", sessionName}
+		if p.ZellijLayout != "" {
+			args = append(args, "--layout", zellijLayout(workingDir, p.ZellijLayout))
+		}
+		args = append(args, backendArgs...)
+	}
+
+	log.Infof("zellij %s", strings.Join(args, " "))
+
+	cmd := exec.Command("zellij", args...)
+	cmd.Dir = workingDir
+	// zellij only applies the environment when it creates the session
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (b *zellijBackend) ListSessions() ([]liveSession, error) {
+	if !path.ExistsInPathOrAsFile("zellij") {
+		return nil, nil
+	}
+	names, err := zellijSessions(false)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]liveSession, 0, len(names))
+	for _, name := range names {
+		sessions = append(sessions, liveSession{Name: name})
+	}
+	return sessions, nil
+}
+
+// RenameSession renames a running session from outside of it. Exited sessions
+// can't be renamed, so they are reported instead of silently keeping the old
+// name.
+func (b *zellijBackend) RenameSession(from, to string) (bool, error) {
+	if !path.ExistsInPathOrAsFile("zellij") {
+		return false, nil
+	}
+	names, err := zellijSessions(true)
+	if err != nil {
+		return false, err
+	}
+	live, err := zellijSessions(false)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case slices.Contains(live, from):
+		if err := exec.Command("zellij", "--session", from, "action", "rename-session", to).Run(); err != nil {
+			return false, fmt.Errorf("failed to rename zellij session '%s': %w", from, err)
+		}
+		return true, nil
+	case slices.Contains(names, from):
+		return false, fmt.Errorf("zellij session '%s' has exited and can't be renamed, remove it with 'zellij delete-session %s'", from, from)
+	}
+	return false, nil
+}
+
+func (b *zellijBackend) KillSession(name string) error {
+	return exec.Command("zellij", "delete-session", "--force", name).Run()
+}
+
+// zellijLayout resolves layout files relative to the project directory while
+// keeping built-in layout names, like "compact", as they are.
+func zellijLayout(workingDir, layout string) string {
+	if strings.Contains(layout, "/") || strings.HasSuffix(layout, ".kdl") {
+		return layoutDir(workingDir, layout)
+	}
+	return layout
+}
+
+// zellijSessionExists also reports exited sessions, attaching to them
+// resurrects the session instead of failing on the duplicated name.
+func zellijSessionExists(session string) (bool, error) {
+	names, err := zellijSessions(true)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(names, session), nil
+}
+
+func zellijSessions(exited bool) ([]string, error) {
+	out, err := exec.Command("zellij", "list-sessions", "--short", "--no-formatting").CombinedOutput()
+	if err != nil {
+		// zellij fails when there are no sessions
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list zellij sessions: %w", err)
+	}
+	return parseZellijSessions(string(out), exited), nil
+}
+
+// parseZellijSessions keeps the session names from list-sessions, ignoring
+// the creation details printed by older zellij releases. Exited sessions,
+// which can only be resurrected, are skipped unless exited is set.
+func parseZellijSessions(out string, exited bool) []string {
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if !exited && strings.Contains(line, "EXITED") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseZellijSessions(t *testing.T) {
+	out := "api\nweb [Created 2h ago] (EXITED - attach to resurrect)\n\n"
+	names := parseZellijSessions(out, false)
+	if strings.Join(names, ",") != "api" {
+		t.Fatalf("unexpected sessions: %v", names)
+	}
+	if names := parseZellijSessions(out, true); strings.Join(names, ",") != "api,web" {
+		t.Fatalf("expected exited sessions to be kept, got %v", names)
+	}
+	if names := parseZellijSessions("", false); len(names) != 0 {
+		t.Fatalf("expected no sessions, got %v", names)
+	}
+}
+
+func TestZellijLayout(t *testing.T) {
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"compact", "compact"},
+		{"dev.kdl", "/src/proj/dev.kdl"},
+		{".zellij/dev.kdl", "/src/proj/.zellij/dev.kdl"},
+		{"/etc/zellij/dev.kdl", "/etc/zellij/dev.kdl"},
+	}
+	for _, tt := range tests {
+		if got := zellijLayout("/src/proj", tt.layout); got != tt.expected {
+			t.Errorf("zellijLayout(%q) = %q, want %q", tt.layout, got, tt.expected)
+		}
+	}
+}
+
+func TestZellijBackendRenamesSessions(t *testing.T) {
+	if _, ok := newZellijBackend().(sessionRenamer); !ok {
+		t.Fatal("expected zellij backend to rename sessions on project rename")
+	}
+}
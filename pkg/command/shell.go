@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -21,6 +22,8 @@ func init() {
 		Short:   fmt.Sprintf("Open project using Shell (%s current)", CurrentShell()),
 		Aliases: []string{"sh", "nu", "bash", "zsh"},
 		RunE:    shell,
+
+		ValidArgsFunction: completeProjectNames,
 	}
 	shellCmd.Flags().BoolVar(&shellNoWorkspaceDir, "no-workspace-dir", false, "Don't create workspace shell directory, use workspace parent")
 	shellCmd.Flags().BoolVar(&shellNoRebuildWorkspaceDir, "no-rebuild-workspace-dir", false, "Don't rebuild workspace shell directory, create a new temp dir if needed")
@@ -33,18 +36,15 @@ var (
 )
 
 func shell(cmdParam *cobra.Command, params []string) error {
-	if len(params) == 0 {
-		return fmt.Errorf("project name is required")
-	}
-
 	projects, err := project.Load(cfg)
 	if err != nil {
 		return err
 	}
 
-	p, _ := projects.Find(path.SafeName(params...))
-	if p == nil {
-		return fmt.Errorf("project not found")
+	name, pwd := path.SafeName(params...)
+	p, _, err := findProject(projects, name, pwd)
+	if err != nil {
+		return err
 	}
 	if err := p.Validate(); err != nil {
 		return err
@@ -65,14 +65,20 @@ func shell(cmdParam *cobra.Command, params []string) error {
 
 	log.Infof("shell %s on '%s'", shell, p.RootPath)
 
+	env, err := projectEnvironment(p, nil)
+	if err != nil {
+		return err
+	}
+
 	var (
 		command string
 		args    []string
 		execDir string
+		cmdEnv  []string
 	)
 
-	switch p.ProjectType {
-	case project.ProjectTypeLocal, project.ProjectTypeWSL:
+	switch {
+	case runsLocally(p):
 		if err := path.EnsureExecutable(shell); err != nil {
 			return err
 		}
@@ -92,26 +98,37 @@ func shell(cmdParam *cobra.Command, params []string) error {
 		}
 
 		command = shell
+		cmdEnv = env
 		sep := commandSeparator(shell)
 		args = []string{"-c", fmt.Sprintf("cd %s %s exec %s", execDir, sep, shell)}
 
-	case project.ProjectTypeSSH:
-		// SSH connections use the remote default shell, not the local alias
+	case isRemote(p):
+		// remote connections use the remote default shell, not the local alias
 		if cmdParam.CalledAs() != "shell" && cmdParam.CalledAs() != "sh" {
-			log.Infof("warning: SSH connections use the remote server's default shell, ignoring '%s' alias", cmdParam.CalledAs())
+			log.Infof("warning: %s connections use the remote server's default shell, ignoring '%s' alias", p.ProjectType, cmdParam.CalledAs())
 			shell = CurrentShell()
 		}
+		if p.ProjectType == project.ProjectTypeContainer {
+			shell = containerShell
+		}
 
-		log.Infof("opening shell on ssh host")
-		sshHost, sshPath, err := p.SSHInfo()
+		log.Infof("opening shell on %s host", p.ProjectType)
+		sshHost, sshPath, err := remoteTarget(p)
 		if err != nil {
 			return err
 		}
 
-		command = "ssh"
 		sep := commandSeparator(shell)
-		args = []string{sshHost, "-t", fmt.Sprintf("cd %s %s exec %s", sshPath, sep, shell)}
-		execDir = "" // SSH doesn't use local workDir
+		remoteCmd := fmt.Sprintf("cd %s %s ", sshPath, sep)
+		if len(env) > 0 {
+			remoteCmd += fmt.Sprintf("%s %s ", exportCommand(env), sep)
+		}
+		sshCmd, err := remoteCommand(context.Background(), p, remoteCmd+"exec "+shell, sshInteractive, workspaceForwards(p, sshHost)...)
+		if err != nil {
+			return err
+		}
+		command, args = sshCmd.Path, sshCmd.Args[1:]
+		execDir = "" // remote projects don't use local workDir
 
 	default:
 		return fmt.Errorf("project type %s not supported", p.ProjectType)
@@ -119,6 +136,9 @@ func shell(cmdParam *cobra.Command, params []string) error {
 
 	cmd := exec.Command(command, args...)
 	cmd.Dir = execDir
+	if len(cmdEnv) > 0 {
+		cmd.Env = append(os.Environ(), cmdEnv...)
+	}
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -244,6 +264,24 @@ func shellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\\''") + "'"
 }
 
+// exportCommand builds a POSIX export statement for KEY=VALUE pairs, used to
+// pass the project environment to remote commands.
+// projectEnvironment returns the project environment for where its commands
+// run: KEY=VALUE pairs for local processes and, for remote projects, shell
+// assignments for exportCommand that leave variables the project does not
+// declare to the remote shell.
+func projectEnvironment(p *project.Project, extra map[string]string) ([]string, error) {
+	if isRemote(p) {
+		return p.ShellEnvironmentWith(extra)
+	}
+	return p.EnvironmentWith(extra)
+}
+
+// exportCommand exports the shell assignments of a remote project environment.
+func exportCommand(env []string) string {
+	return "export " + strings.Join(env, " ")
+}
+
 func commandSeparator(shell string) string {
 	// nushell doesn't support && operator
 	if strings.Contains(shell, "nu") {
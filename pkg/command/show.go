@@ -0,0 +1,76 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:               "show [project]",
+		Short:             "Show details of a project",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeProjectNames,
+		RunE:              show,
+	}
+	rootCmd.AddCommand(cmd)
+}
+
+func show(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	name, pwd := path.SafeName(params...)
+	p, _, err := findProject(projects, name, pwd)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range projectDetails(p) {
+		if field[1] == "" {
+			continue
+		}
+		log.Printf("%-10s %s\n", field[0]+":", field[1])
+	}
+	return nil
+}
+
+func projectDetails(p *project.Project) [][2]string {
+	details := [][2]string{
+		{"name", p.Name},
+		{"alias", strings.Join(p.Aliases, ", ")},
+		{"type", string(p.ProjectType)},
+		{"path", p.RootPath},
+		{"group", p.Group},
+		{"tags", strings.Join(p.Tags, ", ")},
+		{"scm", p.SCM},
+	}
+	if p.IsWorkspace {
+		details = append(details, [2]string{"workspace", "true"})
+	}
+	if distro, _, err := p.WSLInfo(); err == nil {
+		details = append(details, [2]string{"distro", distro})
+	}
+	if c, _, err := p.ContainerInfo(); err == nil {
+		details = append(details, [2]string{"container", containerLabel(c)})
+	}
+	if p.ProjectType == project.ProjectTypeSSH {
+		if r, err := p.ResolveSSH(); err == nil {
+			details = append(details,
+				[2]string{"hostname", r.HostName},
+				[2]string{"user", r.User},
+				[2]string{"port", r.Port},
+			)
+		}
+	}
+	if !p.ValidPath {
+		details = append(details, [2]string{"valid", "false"})
+	}
+	return details
+}
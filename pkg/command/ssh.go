@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+)
+
+const sshURIPrefix = "vscode-remote://ssh-remote+"
+
+// sshMode tells how a remote command interacts with the user.
+type sshMode int
+
+const (
+	// sshBatch runs the command without a terminal
+	sshBatch sshMode = iota
+	// sshTerminal allocates a terminal, keeping ssh so the exit code is kept
+	sshTerminal
+	// sshInteractive is for shells and sessions, which may go through mosh
+	// or autossh
+	sshInteractive
+)
+
+// sshCommand runs remoteCmd on the host of an SSH project using the
+// configured transport and the project ssh options.
+// Extra ssh options, like tunnels, follow the project ones.
+func sshCommand(ctx context.Context, p *project.Project, host, remoteCmd string, mode sshMode, extra ...string) (*exec.Cmd, error) {
+	transport, err := p.SSHTransport(cfg.SSHTransport)
+	if err != nil {
+		return nil, err
+	}
+	name, args := sshArgs(transport, append(p.SSH.Args(), extra...), host, remoteCmd, mode)
+	if err := path.EnsureExecutable(name); err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, name, args...), nil
+}
+
+func sshArgs(transport string, options []string, host, remoteCmd string, mode sshMode) (string, []string) {
+	if transport == project.TransportMosh && mode == sshInteractive {
+		var args []string
+		if len(options) > 0 {
+			args = append(args, "--ssh="+quoteArgs(append([]string{"ssh"}, options...)))
+		}
+		return "mosh", append(args, host, "--", "sh", "-c", remoteCmd)
+	}
+
+	name := "ssh"
+	var args []string
+	if transport == project.TransportAutoSSH && mode == sshInteractive {
+		// -M 0 relies on ServerAlive options instead of a monitor port
+		name = "autossh"
+		args = append(args, "-M", "0")
+	}
+	args = append(args, options...)
+	if mode != sshBatch {
+		args = append(args, "-t")
+	}
+	args = append(args, host)
+	if remoteCmd != "" {
+		args = append(args, remoteCmd)
+	}
+	return name, args
+}
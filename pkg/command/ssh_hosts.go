@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/filipenos/projects/pkg/sshconfig"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "ssh-hosts [host...]",
+		Short: "List the hosts of the ssh config and register them as projects",
+		Long: `List the hosts declared in ~/.ssh/config, following Include, with the
+resolved HostName, User and Port and the projects using them.
+
+With --add the hosts without project are registered as SSH projects named
+after the host, optionally only the hosts given as arguments.`,
+		RunE: sshHosts,
+
+		ValidArgsFunction: completeSSHHosts,
+	}
+	cmd.Flags().Bool("add", false, "Register the hosts without project as SSH projects")
+	cmd.Flags().String("path", "", "Remote directory of the registered projects (default is the remote $HOME, read over ssh)")
+	cmd.Flags().String("group", "", "Group of the registered projects")
+	rootCmd.AddCommand(cmd)
+}
+
+func sshHosts(cmdParam *cobra.Command, params []string) error {
+	c, err := sshconfig.LoadDefault()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := selectSSHHosts(c.Aliases(), params)
+	if err != nil {
+		return err
+	}
+
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	byHost := sshHostProjects(projects)
+
+	if SafeBoolFlag(cmdParam, "add") {
+		return addSSHHosts(projects, hosts, byHost, SafeStringFlag(cmdParam, "path"), SafeStringFlag(cmdParam, "group"))
+	}
+
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOST\tHOSTNAME\tUSER\tPORT\tPROJECTS")
+	for _, host := range hosts {
+		r := c.Resolve(host)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", host, r.HostName, r.User, r.Port, strings.Join(byHost[host], ", "))
+	}
+	return w.Flush()
+}
+
+func addSSHHosts(projects project.Projects, hosts []string, byHost map[string][]string, dir, group string) error {
+	var added int
+	for _, host := range hosts {
+		if len(byHost[host]) > 0 {
+			log.Infof("skip: '%s' already used by %s", host, strings.Join(byHost[host], ", "))
+			continue
+		}
+		remoteDir := dir
+		if remoteDir == "" {
+			home, err := remoteHome(host)
+			if err != nil {
+				log.Warnf("skip: %v, use --path to set the directory", err)
+				continue
+			}
+			remoteDir = home
+		}
+		p := project.Project{
+			Name:     host,
+			RootPath: sshURIPrefix + host + "/" + strings.TrimPrefix(remoteDir, "/"),
+			Group:    group,
+			Enabled:  true,
+		}
+		if err := projects.CheckConflicts(&p, -1); err != nil {
+			log.Warnf("skip: %v", err)
+			continue
+		}
+		projects = append(projects, p)
+		log.Infof("Add project: '%s' path: '%s'", p.Name, p.RootPath)
+		added++
+	}
+
+	if added == 0 {
+		log.Infof("no hosts to add")
+		return nil
+	}
+	if err := projects.Save(cfg); err != nil {
+		return err
+	}
+	log.Infof("added %d project(s)", added)
+	return nil
+}
+
+// selectSSHHosts keeps the requested hosts, all of them when none is given.
+func selectSSHHosts(aliases, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return aliases, nil
+	}
+	known := map[string]bool{}
+	for _, alias := range aliases {
+		known[alias] = true
+	}
+	for _, host := range requested {
+		if !known[host] {
+			return nil, fmt.Errorf("host '%s' not found in %s", host, sshconfig.DefaultPath())
+		}
+	}
+	return requested, nil
+}
+
+// sshHostProjects maps each ssh host to the projects using it.
+func sshHostProjects(projects project.Projects) map[string][]string {
+	byHost := map[string][]string{}
+	for _, p := range projects {
+		if p.ProjectType != project.ProjectTypeSSH {
+			continue
+		}
+		if host, err := p.SSHHost(); err == nil {
+			byHost[host] = append(byHost[host], p.Name)
+		}
+	}
+	for host := range byHost {
+		sort.Strings(byHost[host])
+	}
+	return byHost
+}
+
+// remoteHome logs in to host without prompts and returns its $HOME.
+var remoteHome = func(host string) (string, error) {
+	p := &project.Project{Name: host, RootPath: sshURIPrefix + host + "/"}
+	p.Init()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	cmd, err := sshCommand(ctx, p, host, `printf %s "$HOME"`, sshBatch, "-o", "BatchMode=yes", "-o", "ConnectTimeout=10")
+	if err != nil {
+		return "", err
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the home directory on %s: %w", host, err)
+	}
+	home := strings.TrimSpace(string(out))
+	if !strings.HasPrefix(home, "/") {
+		return "", fmt.Errorf("unexpected home directory %q on %s", home, host)
+	}
+	return home, nil
+}
+
+func completeSSHHosts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := sshconfig.LoadDefault()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return completeFixedValues(c.Aliases()...)(cmd, args, toComplete)
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestSelectSSHHosts(t *testing.T) {
+	aliases := []string{"devbox", "build"}
+
+	hosts, err := selectSSHHosts(aliases, nil)
+	if err != nil || strings.Join(hosts, ",") != "devbox,build" {
+		t.Fatalf("expected every host, got %v %v", hosts, err)
+	}
+	hosts, err = selectSSHHosts(aliases, []string{"build"})
+	if err != nil || strings.Join(hosts, ",") != "build" {
+		t.Fatalf("expected requested host, got %v %v", hosts, err)
+	}
+	if _, err := selectSSHHosts(aliases, []string{"missing"}); err == nil {
+		t.Fatal("expected error for unknown host")
+	}
+}
+
+func TestSSHHostProjects(t *testing.T) {
+	projects := project.Projects{
+		{Name: "web", RootPath: "vscode-remote://ssh-remote+devbox/srv/web"},
+		{Name: "api", RootPath: "vscode-remote://ssh-remote+alice@devbox/srv/api"},
+		{Name: "local", RootPath: "/src/local"},
+	}
+	for i := range projects {
+		projects[i].Init()
+	}
+
+	byHost := sshHostProjects(projects)
+	if len(byHost) != 1 || strings.Join(byHost["devbox"], ",") != "api,web" {
+		t.Fatalf("unexpected hosts: %v", byHost)
+	}
+}
+
+func TestAddSSHHosts(t *testing.T) {
+	originalHome, originalCfg := remoteHome, cfg
+	defer func() { remoteHome, cfg = originalHome, originalCfg }()
+	cfg.ProjectLocation = filepath.Join(t.TempDir(), "projects.json")
+	remoteHome = func(host string) (string, error) {
+		if host == "down" {
+			return "", fmt.Errorf("connection refused")
+		}
+		return "/home/" + host, nil
+	}
+
+	projects := project.Projects{{Name: "web", RootPath: "vscode-remote://ssh-remote+devbox/srv/web"}}
+	byHost := map[string][]string{"devbox": {"web"}}
+	if err := addSSHHosts(projects, []string{"devbox", "build", "down"}, byHost, "", "infra"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, err := project.Load(cfg)
+	if err != nil {
+		t.Fatalf("failed to load projects: %v", err)
+	}
+	if len(saved) != 2 || saved[1].Name != "build" || saved[1].RootPath != "vscode-remote://ssh-remote+build/home/build" || saved[1].Group != "infra" {
+		t.Fatalf("unexpected projects: %+v", saved)
+	}
+}
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestSSHArgs(t *testing.T) {
+	options := []string{"-J", "bastion", "-o", "ServerAliveInterval=30"}
+	tests := []struct {
+		transport string
+		mode      sshMode
+		expected  string
+	}{
+		{project.TransportSSH, sshBatch, "ssh -J bastion -o ServerAliveInterval=30 dev ls"},
+		{project.TransportSSH, sshInteractive, "ssh -J bastion -o ServerAliveInterval=30 -t dev ls"},
+		{project.TransportAutoSSH, sshInteractive, "autossh -M 0 -J bastion -o ServerAliveInterval=30 -t dev ls"},
+		{project.TransportAutoSSH, sshTerminal, "ssh -J bastion -o ServerAliveInterval=30 -t dev ls"},
+		{project.TransportAutoSSH, sshBatch, "ssh -J bastion -o ServerAliveInterval=30 dev ls"},
+		{project.TransportMosh, sshInteractive, "mosh --ssh='ssh' '-J' 'bastion' '-o' 'ServerAliveInterval=30' dev -- sh -c ls"},
+		{project.TransportMosh, sshTerminal, "ssh -J bastion -o ServerAliveInterval=30 -t dev ls"},
+		{project.TransportMosh, sshBatch, "ssh -J bastion -o ServerAliveInterval=30 dev ls"},
+	}
+	for _, tt := range tests {
+		name, args := sshArgs(tt.transport, options, "dev", "ls", tt.mode)
+		if got := name + " " + strings.Join(args, " "); got != tt.expected {
+			t.Errorf("sshArgs(%s, %d) = %q, want %q", tt.transport, tt.mode, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/filipenos/projects/pkg/git"
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the git status of every local project",
+		Args:  cobra.NoArgs,
+		RunE:  status,
+	}
+	cmd.Flags().String("group", "", "Show only projects of the group")
+	cmd.Flags().StringSlice("tag", nil, "Show only projects with the tag (repeat for AND)")
+	cmd.Flags().Bool("dirty", false, "Show only projects with local changes or untracked files")
+	cmd.Flags().Bool("behind", false, "Show only projects behind their upstream")
+	cmd.Flags().Bool("json", false, "Print the status as JSON")
+	cmd.Flags().IntP("jobs", "j", 8, "Maximum number of repositories inspected at the same time")
+	cmd.RegisterFlagCompletionFunc("group", completeProjectField(projectGroups))
+	cmd.RegisterFlagCompletionFunc("tag", completeProjectField(projectTags))
+	rootCmd.AddCommand(cmd)
+}
+
+type projectStatus struct {
+	Project string `json:"project"`
+	Path    string `json:"path"`
+	*git.Status
+	Error string `json:"error,omitempty"`
+}
+
+func status(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+	repos := gitProjects(selectProjects(projects, SafeStringFlag(cmdParam, "group"), SafeStringSliceFlag(cmdParam, "tag")))
+	jobs, _ := cmdParam.Flags().GetInt("jobs")
+
+	statuses := make([]projectStatus, len(repos))
+	runParallel(context.Background(), len(repos), jobs, false, func(ctx context.Context, i int) error {
+		dir := projectWorkingDir(&repos[i])
+		statuses[i] = projectStatus{Project: repos[i].Name, Path: dir}
+		s, err := git.GetStatus(ctx, dir)
+		if err != nil {
+			statuses[i].Error = err.Error()
+			return err
+		}
+		statuses[i].Status = s
+		return nil
+	})
+
+	onlyDirty, onlyBehind := SafeBoolFlag(cmdParam, "dirty"), SafeBoolFlag(cmdParam, "behind")
+	filtered := statuses[:0]
+	for _, s := range statuses {
+		if s.Status != nil && (onlyDirty && !s.IsDirty() || onlyBehind && s.Behind == 0) {
+			continue
+		}
+		filtered = append(filtered, s)
+	}
+
+	if SafeBoolFlag(cmdParam, "json") {
+		enc := json.NewEncoder(log.Output())
+		enc.SetIndent("", "  ")
+		return enc.Encode(filtered)
+	}
+	printStatusTable(filtered, time.Now())
+	return nil
+}
+
+// gitProjects returns the local projects whose directory is a git checkout.
+func gitProjects(projects project.Projects) project.Projects {
+	var repos project.Projects
+	for _, p := range projects {
+		if p.ProjectType != project.ProjectTypeLocal || p.IsWorkspace {
+			continue
+		}
+		if git.IsRepo(projectWorkingDir(&p)) {
+			repos = append(repos, p)
+		}
+	}
+	return repos
+}
+
+func printStatusTable(statuses []projectStatus, now time.Time) {
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tBRANCH\tAHEAD\tBEHIND\tDIRTY\tUNTRACKED\tSTASH\tLAST COMMIT")
+	for _, s := range statuses {
+		if s.Status == nil {
+			fmt.Fprintf(w, "%s\terror: %s\n", s.Project, s.Error)
+			continue
+		}
+		ahead, behind := fmt.Sprint(s.Ahead), fmt.Sprint(s.Behind)
+		if s.Upstream == "" {
+			ahead, behind = "-", "-"
+		}
+		dirty := fmt.Sprint(s.Dirty)
+		if s.Conflicts > 0 {
+			dirty += fmt.Sprintf(" (%d conflicts)", s.Conflicts)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			s.Project, s.Branch, ahead, behind, dirty, s.Untracked, s.Stashes, formatAge(s.LastCommit, now))
+	}
+	w.Flush()
+}
+
+// formatAge prints a compact, human friendly age like 5m, 3h, 2d or 4mo.
+func formatAge(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
@@ -0,0 +1,28 @@
+package command
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[time.Duration]string{
+		30 * time.Second:     "now",
+		5 * time.Minute:      "5m",
+		3 * time.Hour:        "3h",
+		50 * time.Hour:       "2d",
+		65 * 24 * time.Hour:  "2mo",
+		800 * 24 * time.Hour: "2y",
+	}
+	for age, expected := range cases {
+		if got := formatAge(now.Add(-age), now); got != expected {
+			t.Fatalf("formatAge(%s) = %s, expected %s", age, got, expected)
+		}
+	}
+	if got := formatAge(time.Time{}, now); got != "-" {
+		t.Fatalf("expected '-' for zero time, got %s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/filipenos/projects/pkg/project"
@@ -46,3 +47,64 @@ func TestProjectWorkingDir(t *testing.T) {
 		t.Fatalf("expected fallback to RootPath, got %s", got)
 	}
 }
+
+func TestMaskEnvArgs(t *testing.T) {
+	t.Parallel()
+
+	args := []string{"new-session", "-s", "proj", "-e", "TOKEN=secret", "-e", "EMPTY="}
+	got := strings.Join(maskEnvArgs(args), " ")
+	expected := "new-session -s proj -e TOKEN=*** -e EMPTY=***"
+	if got != expected {
+		t.Fatalf("maskEnvArgs returned %q, expected %q", got, expected)
+	}
+	if args[4] != "TOKEN=secret" {
+		t.Fatalf("maskEnvArgs must not modify its input")
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	t.Parallel()
+
+	got := exportCommand([]string{"A='1'", `B="${HOME}"'/bin'`})
+	expected := `export A='1' B="${HOME}"'/bin'`
+	if got != expected {
+		t.Fatalf("exportCommand returned %q, expected %q", got, expected)
+	}
+}
+
+func TestParseTmuxSessions(t *testing.T) {
+	t.Parallel()
+
+	sessions := parseTmuxSessions("api 1\nmy session 0\n")
+	if len(sessions) != 2 {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+	if sessions[0].Name != "api" || !sessions[0].Attached {
+		t.Fatalf("unexpected first session: %+v", sessions[0])
+	}
+	if sessions[1].Name != "my session" || sessions[1].Attached {
+		t.Fatalf("unexpected second session: %+v", sessions[1])
+	}
+	if len(parseTmuxSessions("")) != 0 {
+		t.Fatalf("expected no sessions for empty output")
+	}
+}
+
+func TestParseScreenSessions(t *testing.T) {
+	t.Parallel()
+
+	out := "There are screens on:\n" +
+		"\t4321.api\t(10/19/2026 05:22:01 PM)\t(Detached)\n" +
+		"\t1234.web\t(Attached)\n" +
+		"2 Sockets in /run/screen/S-user.\n"
+	sessions := parseScreenSessions(out)
+	if len(sessions) != 2 {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+	if sessions[0].Name != "api" || sessions[0].Attached {
+		t.Fatalf("unexpected first session: %+v", sessions[0])
+	}
+	if sessions[1].Name != "web" || !sessions[1].Attached {
+		t.Fatalf("unexpected second session: %+v", sessions[1])
+	}
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+// tunnelTarget is the data given to tunnel_command templates.
+type tunnelTarget struct {
+	// Tunnel is the tunnel name from the project uri
+	Tunnel string
+	// Path is the remote project directory
+	Path string
+	// Command is the remote command, already quoted for a POSIX shell
+	Command string
+	// TTY is set for interactive commands
+	TTY bool
+}
+
+// tunnelCommand runs remoteCmd on a tunnel project through the configured
+// command template, since VS Code tunnels can't be reached from a terminal.
+func tunnelCommand(ctx context.Context, p *project.Project, remoteCmd string, mode sshMode) (*exec.Cmd, error) {
+	tunnel, dir, err := p.TunnelInfo()
+	if err != nil {
+		return nil, err
+	}
+	tmpl := cfg.TunnelCommand
+	if p.TunnelCommand != "" {
+		tmpl = p.TunnelCommand
+	}
+	if tmpl == "" {
+		return nil, fmt.Errorf("no tunnel_command configured to reach tunnel '%s', set it in the config or tunnelCommand in the project", tunnel)
+	}
+
+	line, err := renderTunnelCommand(tmpl, tunnelTarget{
+		Tunnel:  tunnel,
+		Path:    dir,
+		Command: shellQuote(remoteCmd),
+		TTY:     mode != sshBatch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line), nil
+}
+
+func renderTunnelCommand(tmpl string, target tunnelTarget) (string, error) {
+	t, err := template.New("tunnel_command").Option("missingkey=error").Funcs(template.FuncMap{
+		"quote": shellQuote,
+	}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid tunnel_command: %w", err)
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, target); err != nil {
+		return "", fmt.Errorf("invalid tunnel_command: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
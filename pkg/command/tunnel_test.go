@@ -0,0 +1,61 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/project"
+)
+
+func TestRenderTunnelCommand(t *testing.T) {
+	target := tunnelTarget{Tunnel: "my box", Path: "/srv/api", Command: shellQuote("cd /srv/api && ls"), TTY: true}
+
+	got, err := renderTunnelCommand(`ssh {{if .TTY}}-t {{end}}{{quote .Tunnel}} {{.Command}}`, target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `ssh -t 'my box' 'cd /srv/api && ls'`; got != expected {
+		t.Fatalf("got %q, want %q", got, expected)
+	}
+
+	if _, err := renderTunnelCommand(`ssh {{.Host}}`, target); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
+
+func TestTunnelCommand(t *testing.T) {
+	original := cfg.TunnelCommand
+	defer func() { cfg.TunnelCommand = original }()
+
+	p := &project.Project{Name: "api", RootPath: "vscode-remote://tunnel+devbox/srv/api"}
+	p.Init()
+
+	cfg.TunnelCommand = ""
+	if _, err := tunnelCommand(context.Background(), p, "true", sshBatch); err == nil {
+		t.Fatal("expected error without tunnel_command")
+	}
+
+	// a template running the command locally stands in for the tunnel
+	cfg.TunnelCommand = `echo {{.Tunnel}} {{.Path}}; sh -c {{.Command}}`
+	cmd, err := tunnelCommand(context.Background(), p, "echo 'it works'", sshBatch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "devbox /srv/api\nit works" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+
+	p.TunnelCommand = `echo project {{.Tunnel}}`
+	cmd, err = tunnelCommand(context.Background(), p, "true", sshBatch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out, _ := cmd.Output(); strings.TrimSpace(string(out)) != "project devbox" {
+		t.Fatalf("expected project template to win, got %q", out)
+	}
+}
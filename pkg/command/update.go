@@ -18,6 +18,8 @@ var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update data of existing project",
 	RunE:  update,
+
+	ValidArgsFunction: completeProjectNames,
 }
 
 func update(cmdParam *cobra.Command, params []string) error {
@@ -31,9 +33,12 @@ func update(cmdParam *cobra.Command, params []string) error {
 		return err
 	}
 
-	_, index := projects.Get(name)
-	if index == -1 {
-		return fmt.Errorf("project '%s' not found", name)
+	_, index, err := projects.Lookup(name)
+	if err != nil {
+		if err == project.ErrProjectNotFound {
+			return fmt.Errorf("project '%s' not found", name)
+		}
+		return err
 	}
 	p := &projects[index]
 
@@ -45,14 +50,35 @@ func update(cmdParam *cobra.Command, params []string) error {
 		return project.ErrNameRequired
 	}
 	if !SafeBoolFlag(cmdParam, "no-validate") {
-		if edited.RootPath == "" {
-			return project.ErrPathRequired
+		if err := validateProjectPath(edited); err != nil {
+			return err
 		}
-		if !path.Exist(edited.RootPath) {
-			return project.ErrPathNoExist
+	}
+
+	// keep the fields that are not editable, like tags and environment
+	updated := *p
+	updated.Name = edited.Name
+	updated.Aliases = edited.Aliases
+	updated.RootPath = edited.RootPath
+	updated.Group = edited.Group
+	updated.Enabled = edited.Enabled
+
+	if err := projects.CheckConflicts(&updated, index); err != nil {
+		return err
+	}
+
+	// renaming goes through Rename so worktree children follow the parent
+	oldName := p.Name
+	if updated.Name != oldName {
+		if _, err := projects.Rename(oldName, updated.Name, false); err != nil {
+			return err
 		}
 	}
 
-	projects[index] = *edited
-	return projects.Save(cfg)
+	projects[index] = updated
+	if err := projects.Save(cfg); err != nil {
+		return err
+	}
+	renameSessions(sanitizeSessionName(oldName), sanitizeSessionName(updated.Name))
+	return nil
 }
@@ -0,0 +1,198 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/filipenos/projects/pkg/git"
+	"github.com/filipenos/projects/pkg/log"
+	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/project"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	worktreeCmd := &cobra.Command{
+		Use:     "worktree",
+		Aliases: []string{"wt"},
+		Short:   "Manage git worktrees of a project as child projects",
+	}
+
+	addCmd := &cobra.Command{
+		Use:               "add <project> <branch>",
+		Short:             "Create a worktree next to the project and register it",
+		Args:              cobra.ExactArgs(2),
+		RunE:              worktreeAdd,
+		ValidArgsFunction: completeProjectNames,
+	}
+	addCmd.Flags().String("name", "", "Name of the worktree project (default is <project>-<branch>)")
+
+	listCmd := &cobra.Command{
+		Use:               "list <project>",
+		Aliases:           []string{"ls"},
+		Short:             "List the worktrees of the project",
+		Args:              cobra.ExactArgs(1),
+		RunE:              worktreeList,
+		ValidArgsFunction: completeProjectNames,
+	}
+
+	removeCmd := &cobra.Command{
+		Use:               "remove <project> <branch> | <worktree-project>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a worktree and its project",
+		Args:              cobra.RangeArgs(1, 2),
+		RunE:              worktreeRemove,
+		ValidArgsFunction: completeProjectNames,
+	}
+	removeCmd.Flags().Bool("force", false, "Remove the worktree even with local changes")
+
+	worktreeCmd.AddCommand(addCmd, listCmd, removeCmd)
+	rootCmd.AddCommand(worktreeCmd)
+}
+
+func worktreeAdd(cmdParam *cobra.Command, params []string) error {
+	projects, parent, err := loadWorktreeParent(params[0])
+	if err != nil {
+		return err
+	}
+
+	branch := params[1]
+	child := project.Project{
+		Name:     parent.Name + "-" + sanitizeSessionName(branch),
+		RootPath: worktreePath(parent, branch),
+		Group:    parent.Group,
+		Tags:     parent.Tags,
+		Enabled:  true,
+		SCM:      parent.SCM,
+		Parent:   parent.Name,
+	}
+	if name := SafeStringFlag(cmdParam, "name"); name != "" {
+		child.Name = name
+	}
+	if err := projects.CheckConflicts(&child, -1); err != nil {
+		return fmt.Errorf("%w, use --name to choose another name", err)
+	}
+
+	if err := git.AddWorktree(context.Background(), parent.RootPath, child.RootPath, branch); err != nil {
+		return err
+	}
+
+	projects = append(projects, child)
+	if err := projects.Save(cfg); err != nil {
+		return err
+	}
+	log.Infof("Add worktree project: '%s' path: '%s'", child.Name, child.RootPath)
+	return nil
+}
+
+func worktreeList(cmdParam *cobra.Command, params []string) error {
+	projects, parent, err := loadWorktreeParent(params[0])
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := git.ListWorktrees(context.Background(), parent.RootPath)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(log.Output(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tBRANCH\tPATH")
+	for _, wt := range worktrees {
+		name := "-"
+		if p, _ := projects.GetByPath(wt.Path); p != nil {
+			name = p.Name
+		}
+		branch := wt.Branch
+		if wt.Detached {
+			branch = "(detached)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, branch, wt.Path)
+	}
+	return w.Flush()
+}
+
+func worktreeRemove(cmdParam *cobra.Command, params []string) error {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return err
+	}
+
+	var child *project.Project
+	if len(params) == 1 {
+		child, _, err = projects.Lookup(params[0])
+		if err != nil {
+			return err
+		}
+		if child.Parent == "" {
+			return fmt.Errorf("project '%s' is not a worktree, use: projects worktree remove <project> <branch>", child.Name)
+		}
+	} else {
+		parent, _, err := projects.Lookup(params[0])
+		if err != nil {
+			return err
+		}
+		wtPath := worktreePath(parent, params[1])
+		for i := range projects {
+			if projects[i].Parent == parent.Name && projects[i].RootPath == wtPath {
+				child = &projects[i]
+				break
+			}
+		}
+		if child == nil {
+			return fmt.Errorf("no worktree of '%s' registered for branch '%s'", parent.Name, params[1])
+		}
+	}
+
+	parent, _, err := projects.Lookup(child.Parent)
+	if err != nil {
+		return fmt.Errorf("parent project of '%s': %w", child.Name, err)
+	}
+	ctx := context.Background()
+	if path.Exist(child.RootPath) {
+		if err := git.RemoveWorktree(ctx, parent.RootPath, child.RootPath, SafeBoolFlag(cmdParam, "force")); err != nil {
+			return err
+		}
+	} else {
+		log.Warnf("worktree '%s' no longer exists, pruning it", child.RootPath)
+		if err := git.PruneWorktrees(ctx, parent.RootPath); err != nil {
+			return err
+		}
+	}
+
+	name := child.Name
+	aux := make(project.Projects, 0, len(projects))
+	for i := range projects {
+		if projects[i].Name != name {
+			aux = append(aux, projects[i])
+		}
+	}
+	if err := aux.Save(cfg); err != nil {
+		return err
+	}
+	log.Infof("Worktree project '%s' removed successfully!", name)
+	return nil
+}
+
+func loadWorktreeParent(name string) (project.Projects, *project.Project, error) {
+	projects, err := project.Load(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, _, err := projects.Lookup(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent.ProjectType != project.ProjectTypeLocal || !git.IsRepo(parent.RootPath) {
+		return nil, nil, fmt.Errorf("project '%s' is not a local git repository", parent.Name)
+	}
+	return projects, parent, nil
+}
+
+// worktreePath places the worktree of branch next to the main checkout.
+func worktreePath(parent *project.Project, branch string) string {
+	root := filepath.Clean(parent.RootPath)
+	return filepath.Join(filepath.Dir(root), filepath.Base(root)+"-"+sanitizeSessionName(branch))
+}
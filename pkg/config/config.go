@@ -8,6 +8,9 @@ import (
 	"path/filepath"
 )
 
+// DefaultCloneLayout is where clone puts repositories, ghq style.
+const DefaultCloneLayout = "~/src/{{.Host}}/{{.Owner}}/{{.Repo}}"
+
 var (
 	projectsConf    = fmt.Sprintf("%s/.projects.conf.json", os.Getenv("HOME"))
 	projectsPath    = fmt.Sprintf("%s/.projects.json", os.Getenv("HOME"))
@@ -15,6 +18,7 @@ var (
 		ProjectLocation: projectsPath,
 		Editor:          "code",
 		SessionBackend:  "tmux",
+		CloneLayout:     DefaultCloneLayout,
 	}
 )
 
@@ -23,6 +27,9 @@ type Config struct {
 	ProjectLocation string `json:"projects_location"`
 	Editor          string `json:"editor"`
 	SessionBackend  string `json:"session_backend,omitempty"`
+	CloneLayout     string `json:"clone_layout,omitempty"`
+	SSHTransport    string `json:"ssh_transport,omitempty"`
+	TunnelCommand   string `json:"tunnel_command,omitempty"`
 }
 
 // Load load configuration used on projects
@@ -24,7 +24,9 @@ type Editor struct {
 	Executable string
 	Aliases    []string
 	LocalOnly  bool
-	BuildArgs  func(p *project.Project, window WindowType) ([]string, error)
+	// Terminal editors run in the foreground until the user quits them
+	Terminal  bool
+	BuildArgs func(p *project.Project, window WindowType) ([]string, error)
 }
 
 var editors = []Editor{
@@ -34,8 +36,8 @@ var editors = []Editor{
 	vscodeEditor("windsurf", "windsurf", nil),
 	vscodeEditor("antigravity", "antigravity", nil),
 	// Simple editors (local only, just receive the path)
-	simpleEditor("vim", "vim", nil),
-	simpleEditor("nvim", "nvim", nil),
+	terminalEditor("vim", "vim", nil),
+	terminalEditor("nvim", "nvim", nil),
 	simpleEditor("emacs", "emacs", nil),
 	simpleEditor("zed", "zed", nil),
 	{
@@ -79,6 +81,12 @@ func simpleEditor(name, executable string, aliases []string) Editor {
 	}
 }
 
+func terminalEditor(name, executable string, aliases []string) Editor {
+	e := simpleEditor(name, executable, aliases)
+	e.Terminal = true
+	return e
+}
+
 func vscodeEditor(name, executable string, aliases []string) Editor {
 	return Editor{
 		Name:       name,
@@ -99,7 +107,7 @@ func vscodeEditor(name, executable string, aliases []string) Editor {
 			} else {
 				args = append(args, "--folder-uri")
 			}
-			args = append(args, p.RootPath)
+			args = append(args, p.VSCodeURI())
 			return args, nil
 		},
 	}
@@ -121,6 +129,12 @@ func NewService() *Service {
 	return s
 }
 
+// Editor returns the editor registered with the name or alias.
+func (s *Service) Editor(name string) (*Editor, bool) {
+	e, ok := s.byName[name]
+	return e, ok
+}
+
 func (s *Service) Aliases() []string {
 	seen := make(map[string]bool)
 	var aliases []string
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsRepo reports whether dir is the root of a git checkout. The .git entry is
+// a directory on regular clones and a file on worktrees and submodules.
+func IsRepo(dir string) bool {
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// RemoteURL returns the url of the origin remote or an empty string.
+func RemoteURL(dir string) string {
+	out, err := Run(context.Background(), dir, "remote", "get-url", "origin")
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// Run executes git inside dir returning its trimmed output. On failure the
+// error includes what git printed on stderr.
+func Run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Status summarizes the state of a checkout.
+type Status struct {
+	Branch     string    `json:"branch"`
+	Upstream   string    `json:"upstream,omitempty"`
+	Ahead      int       `json:"ahead"`
+	Behind     int       `json:"behind"`
+	Dirty      int       `json:"dirty"`
+	Untracked  int       `json:"untracked"`
+	Conflicts  int       `json:"conflicts"`
+	Stashes    int       `json:"stashes"`
+	LastCommit time.Time `json:"lastCommit"`
+}
+
+// IsDirty reports whether the checkout has local changes or untracked files.
+func (s *Status) IsDirty() bool {
+	return s.Dirty > 0 || s.Untracked > 0 || s.Conflicts > 0
+}
+
+// GetStatus collects the status of the checkout at dir.
+func GetStatus(ctx context.Context, dir string) (*Status, error) {
+	out, err := Run(ctx, dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseStatus(out)
+	if err != nil {
+		return nil, err
+	}
+
+	stashes, err := Run(ctx, dir, "stash", "list")
+	if err != nil {
+		return nil, err
+	}
+	if stashes != "" {
+		status.Stashes = strings.Count(stashes, "\n") + 1
+	}
+
+	// fails on repositories without commits, leaving LastCommit empty
+	if ts, err := Run(ctx, dir, "log", "-1", "--format=%ct"); err == nil && ts != "" {
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit timestamp %q", ts)
+		}
+		status.LastCommit = time.Unix(sec, 0)
+	}
+	return status, nil
+}
+
+// parseStatus parses the output of git status --porcelain=v2 --branch.
+func parseStatus(out string) (*Status, error) {
+	status := &Status{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			status.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			if _, err := fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &status.Ahead, &status.Behind); err != nil {
+				return nil, fmt.Errorf("invalid branch.ab line %q: %w", line, err)
+			}
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
+			status.Dirty++
+		case strings.HasPrefix(line, "u "):
+			status.Conflicts++
+		case strings.HasPrefix(line, "? "):
+			status.Untracked++
+		}
+	}
+	return status, scanner.Err()
+}
+
+// Clone clones remote into dir, writing git progress to progress.
+func Clone(ctx context.Context, remote, dir string, progress io.Writer) error {
+	cmd := exec.CommandContext(ctx, "git", "clone", remote, dir)
+	cmd.Stdout = progress
+	cmd.Stderr = progress
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git clone %s: %w", remote, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	out := `# branch.oid 1234567890abcdef
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +2 -3
+1 .M N... 100644 100644 100644 aaa bbb file.go
+2 R. N... 100644 100644 100644 aaa bbb R100 new.go	old.go
+u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go
+? untracked.txt
+? other.txt
+! ignored.log`
+
+	status, err := parseStatus(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Branch != "main" || status.Upstream != "origin/main" {
+		t.Fatalf("unexpected branch info: %+v", status)
+	}
+	if status.Ahead != 2 || status.Behind != 3 {
+		t.Fatalf("unexpected ahead/behind: %+v", status)
+	}
+	if status.Dirty != 2 || status.Conflicts != 1 || status.Untracked != 2 {
+		t.Fatalf("unexpected counters: %+v", status)
+	}
+	if !status.IsDirty() {
+		t.Fatalf("expected status to be dirty")
+	}
+}
+
+// initRepo creates a repository with one commit, skipping the test when git
+// is not installed.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		if _, err := Run(ctx, dir, args...); err != nil {
+			t.Fatalf("failed to prepare repository: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestGetStatus(t *testing.T) {
+	dir := initRepo(t)
+	if !IsRepo(dir) {
+		t.Fatalf("expected %s to be a repository", dir)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	status, err := GetStatus(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if status.Branch != "main" || status.Untracked != 1 || status.Stashes != 0 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if status.LastCommit.IsZero() {
+		t.Fatalf("expected last commit time")
+	}
+	if RemoteURL(dir) != "" {
+		t.Fatalf("expected no remote url")
+	}
+}
+
+func TestCloneFileRemote(t *testing.T) {
+	origin := initRepo(t)
+	dir := filepath.Join(t.TempDir(), "clone")
+
+	if err := Clone(context.Background(), "file://"+origin, dir, io.Discard); err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+	if !IsRepo(dir) {
+		t.Fatalf("expected clone to be a repository")
+	}
+	if got := RemoteURL(dir); got != "file://"+origin {
+		t.Fatalf("unexpected remote url %s", got)
+	}
+}
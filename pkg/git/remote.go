@@ -0,0 +1,63 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Remote holds the parts of a repository url used to lay out clones.
+type Remote struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+var scpLikeRe = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// ParseRemote splits a git url into host, owner and repository name. It
+// understands URLs (https, ssh, git, file) and the scp-like syntax
+// "user@host:owner/repo.git". Nested owners, like GitLab subgroups, are kept
+// together in Owner. file URLs use "local" as host and the parent directory
+// as owner.
+func ParseRemote(remote string) (Remote, error) {
+	remote = strings.TrimSpace(remote)
+	var host, repoPath string
+
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid git url %q: %w", remote, err)
+		}
+		host, repoPath = u.Hostname(), u.Path
+		if u.Scheme == "file" {
+			host = "local"
+		}
+	} else if m := scpLikeRe.FindStringSubmatch(remote); m != nil {
+		host, repoPath = m[1], m[2]
+	} else {
+		return Remote{}, fmt.Errorf("invalid git url %q", remote)
+	}
+
+	repoPath = strings.Trim(strings.TrimSuffix(strings.TrimRight(repoPath, "/"), ".git"), "/")
+	i := strings.LastIndex(repoPath, "/")
+	if host == "" || repoPath == "" || i == len(repoPath)-1 {
+		return Remote{}, fmt.Errorf("invalid git url %q: missing host or repository", remote)
+	}
+
+	r := Remote{Host: host, Repo: repoPath[i+1:]}
+	if i > 0 {
+		r.Owner = repoPath[:i]
+	}
+	if host == "local" {
+		// keep only the parent directory, the full path is not an owner
+		r.Owner = r.Owner[strings.LastIndex(r.Owner, "/")+1:]
+	}
+	for _, part := range strings.Split(r.Owner+"/"+r.Repo, "/") {
+		if part == "." || part == ".." {
+			return Remote{}, fmt.Errorf("invalid git url %q: relative path elements are not allowed", remote)
+		}
+	}
+	return r, nil
+}
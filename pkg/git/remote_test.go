@@ -0,0 +1,32 @@
+package git
+
+import "testing"
+
+func TestParseRemote(t *testing.T) {
+	cases := map[string]Remote{
+		"https://github.com/filipenos/projects.git":    {Host: "github.com", Owner: "filipenos", Repo: "projects"},
+		"https://github.com/filipenos/projects/":       {Host: "github.com", Owner: "filipenos", Repo: "projects"},
+		"git@github.com:filipenos/projects.git":        {Host: "github.com", Owner: "filipenos", Repo: "projects"},
+		"ssh://git@gitlab.com:2222/group/sub/repo.git": {Host: "gitlab.com", Owner: "group/sub", Repo: "repo"},
+		"file:///tmp/remotes/team/service.git":         {Host: "local", Owner: "team", Repo: "service"},
+		"host.example:repo":                            {Host: "host.example", Repo: "repo"},
+	}
+	for input, expected := range cases {
+		got, err := ParseRemote(input)
+		if err != nil {
+			t.Fatalf("ParseRemote(%q) failed: %v", input, err)
+		}
+		if got != expected {
+			t.Fatalf("ParseRemote(%q) = %+v, expected %+v", input, got, expected)
+		}
+	}
+
+	for _, input := range []string{
+		"", "not a url", "https://github.com/",
+		"https://github.com/../repo.git", "git@github.com:owner/...git", "https://github.com/owner/..",
+	} {
+		if _, err := ParseRemote(input); err == nil {
+			t.Fatalf("expected error for %q", input)
+		}
+	}
+}
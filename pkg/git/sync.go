@@ -0,0 +1,111 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// Outcome describes what a sync operation did to a repository.
+type Outcome string
+
+const (
+	OutcomeUpdated  Outcome = "updated"
+	OutcomeUpToDate Outcome = "up-to-date"
+	OutcomeSkipped  Outcome = "skipped"
+)
+
+// Result is the outcome of a sync operation with the reason a repository was
+// skipped, e.g. local changes or a non fast-forward history.
+type Result struct {
+	Outcome Outcome
+	Reason  string
+}
+
+// Fetch updates the remote tracking branches of the repository.
+func Fetch(ctx context.Context, dir string) (Result, error) {
+	if _, err := Run(ctx, dir, "fetch", "--all", "--prune", "--quiet"); err != nil {
+		return Result{}, err
+	}
+	return Result{Outcome: OutcomeUpdated}, nil
+}
+
+// Pull fast-forwards the current branch to its upstream. Repositories with
+// local changes, conflicts or a diverged history are left untouched.
+func Pull(ctx context.Context, dir string) (Result, error) {
+	status, err := GetStatus(ctx, dir)
+	if err != nil {
+		return Result{}, err
+	}
+	if r, skip := checkSyncable(status); skip {
+		return r, nil
+	}
+	if status.Dirty > 0 {
+		return skipped("local changes"), nil
+	}
+
+	if _, err := Run(ctx, dir, "fetch", "--quiet"); err != nil {
+		return Result{}, err
+	}
+	if status, err = GetStatus(ctx, dir); err != nil {
+		return Result{}, err
+	}
+
+	switch {
+	case status.Behind == 0:
+		return Result{Outcome: OutcomeUpToDate}, nil
+	case status.Ahead > 0:
+		return skipped(fmt.Sprintf("diverged from %s (%d ahead, %d behind), not fast-forward", status.Upstream, status.Ahead, status.Behind)), nil
+	}
+
+	if _, err := Run(ctx, dir, "merge", "--ff-only", "--quiet", "@{upstream}"); err != nil {
+		return Result{}, err
+	}
+	return Result{Outcome: OutcomeUpdated, Reason: fmt.Sprintf("%d commit(s) from %s", status.Behind, status.Upstream)}, nil
+}
+
+// Push sends the commits of the current branch to its upstream, skipping
+// repositories that would need a non fast-forward push.
+func Push(ctx context.Context, dir string) (Result, error) {
+	status, err := GetStatus(ctx, dir)
+	if err != nil {
+		return Result{}, err
+	}
+	if r, skip := checkSyncable(status); skip {
+		return r, nil
+	}
+
+	if _, err := Run(ctx, dir, "fetch", "--quiet"); err != nil {
+		return Result{}, err
+	}
+	if status, err = GetStatus(ctx, dir); err != nil {
+		return Result{}, err
+	}
+
+	switch {
+	case status.Behind > 0:
+		return skipped(fmt.Sprintf("%d commit(s) behind %s, not fast-forward", status.Behind, status.Upstream)), nil
+	case status.Ahead == 0:
+		return Result{Outcome: OutcomeUpToDate}, nil
+	}
+
+	if _, err := Run(ctx, dir, "push", "--quiet"); err != nil {
+		return Result{}, err
+	}
+	return Result{Outcome: OutcomeUpdated, Reason: fmt.Sprintf("%d commit(s) to %s", status.Ahead, status.Upstream)}, nil
+}
+
+func checkSyncable(status *Status) (Result, bool) {
+	switch {
+	case status.Conflicts > 0:
+		return skipped(fmt.Sprintf("%d conflicted file(s)", status.Conflicts)), true
+	case status.Branch == "(detached)":
+		return skipped("detached HEAD"), true
+	case status.Upstream == "":
+		return skipped("no upstream branch"), true
+	}
+	return Result{}, false
+}
+
+func skipped(reason string) Result {
+	return Result{Outcome: OutcomeSkipped, Reason: reason}
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupRemote creates a bare repository with one commit and returns it with
+// two clones of it.
+func setupRemote(t *testing.T) (string, string, string) {
+	t.Helper()
+	seed := initRepo(t)
+	base := t.TempDir()
+	bare := filepath.Join(base, "origin.git")
+	ctx := context.Background()
+
+	if _, err := Run(ctx, base, "clone", "-q", "--bare", seed, bare); err != nil {
+		t.Fatalf("failed to create bare repository: %v", err)
+	}
+	clones := make([]string, 2)
+	for i, name := range []string{"one", "two"} {
+		clones[i] = filepath.Join(base, name)
+		if _, err := Run(ctx, base, "clone", "-q", bare, clones[i]); err != nil {
+			t.Fatalf("failed to clone: %v", err)
+		}
+	}
+	return bare, clones[0], clones[1]
+}
+
+func commit(t *testing.T, dir, file string) {
+	t.Helper()
+	ctx := context.Background()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(file), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	for _, args := range [][]string{{"add", file}, {"commit", "-q", "-m", file}} {
+		if _, err := Run(ctx, dir, args...); err != nil {
+			t.Fatalf("failed to commit: %v", err)
+		}
+	}
+}
+
+func TestPushAndPull(t *testing.T) {
+	_, one, two := setupRemote(t)
+	ctx := context.Background()
+
+	if r, err := Push(ctx, one); err != nil || r.Outcome != OutcomeUpToDate {
+		t.Fatalf("expected nothing to push, got %+v %v", r, err)
+	}
+
+	commit(t, one, "a.txt")
+	if r, err := Push(ctx, one); err != nil || r.Outcome != OutcomeUpdated {
+		t.Fatalf("expected push to succeed, got %+v %v", r, err)
+	}
+
+	if r, err := Fetch(ctx, two); err != nil || r.Outcome != OutcomeUpdated {
+		t.Fatalf("expected fetch to succeed, got %+v %v", r, err)
+	}
+	if r, err := Pull(ctx, two); err != nil || r.Outcome != OutcomeUpdated {
+		t.Fatalf("expected pull to fast-forward, got %+v %v", r, err)
+	}
+	if _, err := os.Stat(filepath.Join(two, "a.txt")); err != nil {
+		t.Fatalf("expected pulled file to exist: %v", err)
+	}
+	if r, err := Pull(ctx, two); err != nil || r.Outcome != OutcomeUpToDate {
+		t.Fatalf("expected up to date, got %+v %v", r, err)
+	}
+}
+
+func TestSyncSkipsNonFastForward(t *testing.T) {
+	_, one, two := setupRemote(t)
+	ctx := context.Background()
+
+	commit(t, one, "a.txt")
+	if _, err := Push(ctx, one); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	commit(t, two, "b.txt")
+
+	head, _ := Run(ctx, two, "rev-parse", "HEAD")
+	if r, err := Pull(ctx, two); err != nil || r.Outcome != OutcomeSkipped {
+		t.Fatalf("expected diverged pull to be skipped, got %+v %v", r, err)
+	}
+	if r, err := Push(ctx, two); err != nil || r.Outcome != OutcomeSkipped {
+		t.Fatalf("expected non fast-forward push to be skipped, got %+v %v", r, err)
+	}
+	if after, _ := Run(ctx, two, "rev-parse", "HEAD"); after != head {
+		t.Fatalf("expected repository to be untouched")
+	}
+}
+
+func TestPullSkipsLocalChanges(t *testing.T) {
+	_, one, two := setupRemote(t)
+	ctx := context.Background()
+
+	commit(t, one, "a.txt")
+	if _, err := Push(ctx, one); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	commit(t, two, "b.txt")
+	if _, err := Run(ctx, two, "reset", "-q", "--soft", "HEAD~1"); err != nil {
+		t.Fatalf("failed to reset: %v", err)
+	}
+
+	if r, err := Pull(ctx, two); err != nil || r.Outcome != OutcomeSkipped || r.Reason != "local changes" {
+		t.Fatalf("expected dirty repository to be skipped, got %+v %v", r, err)
+	}
+}
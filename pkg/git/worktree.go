@@ -0,0 +1,93 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"strings"
+)
+
+// Worktree is an entry of git worktree list.
+type Worktree struct {
+	Path     string
+	Head     string
+	Branch   string
+	Bare     bool
+	Detached bool
+}
+
+// AddWorktree creates a worktree of repo at dir checked out at branch. The
+// branch is created from the current HEAD when it does not exist locally or
+// on the origin remote.
+func AddWorktree(ctx context.Context, repo, dir, branch string) error {
+	args := []string{"worktree", "add", dir, branch}
+	if !refExists(ctx, repo, "refs/heads/"+branch) && !refExists(ctx, repo, "refs/remotes/origin/"+branch) {
+		args = []string{"worktree", "add", "-b", branch, dir}
+	}
+	_, err := Run(ctx, repo, args...)
+	return err
+}
+
+// RemoveWorktree removes the worktree at dir. Worktrees with local changes
+// are only removed when force is set.
+func RemoveWorktree(ctx context.Context, repo, dir string, force bool) error {
+	args := []string{"worktree", "remove", dir}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := Run(ctx, repo, args...)
+	return err
+}
+
+// PruneWorktrees drops the administrative files of worktrees whose directory
+// no longer exists.
+func PruneWorktrees(ctx context.Context, repo string) error {
+	_, err := Run(ctx, repo, "worktree", "prune")
+	return err
+}
+
+// ListWorktrees returns the worktrees of repo, the main checkout first.
+func ListWorktrees(ctx context.Context, repo string) ([]Worktree, error) {
+	out, err := Run(ctx, repo, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(out), nil
+}
+
+func parseWorktrees(out string) []Worktree {
+	var (
+		worktrees []Worktree
+		current   *Worktree
+	)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if current != nil {
+				current.Head = value
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "bare":
+			if current != nil {
+				current.Bare = true
+			}
+		case "detached":
+			if current != nil {
+				current.Detached = true
+			}
+		}
+	}
+	return worktrees
+}
+
+func refExists(ctx context.Context, repo, ref string) bool {
+	_, err := Run(ctx, repo, "rev-parse", "--verify", "--quiet", ref)
+	return err == nil
+}
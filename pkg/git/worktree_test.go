@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktrees(t *testing.T) {
+	repo := initRepo(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "feature")
+
+	if err := AddWorktree(ctx, repo, dir, "feature/login"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+	if !IsRepo(dir) {
+		t.Fatalf("expected worktree to be a checkout")
+	}
+
+	worktrees, err := ListWorktrees(ctx, repo)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 2 || worktrees[0].Branch != "main" || worktrees[1].Branch != "feature/login" {
+		t.Fatalf("unexpected worktrees: %+v", worktrees)
+	}
+
+	// the branch exists now, so a second worktree must check it out by name
+	other := filepath.Join(t.TempDir(), "other")
+	if err := AddWorktree(ctx, repo, other, "feature/login"); err == nil {
+		t.Fatalf("expected error when branch is already checked out")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "dirty.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := RemoveWorktree(ctx, repo, dir, false); err == nil {
+		t.Fatalf("expected dirty worktree to require force")
+	}
+	if err := RemoveWorktree(ctx, repo, dir, true); err != nil {
+		t.Fatalf("RemoveWorktree failed: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected worktree directory to be removed")
+	}
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	repo := initRepo(t)
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "gone")
+
+	if err := AddWorktree(ctx, repo, dir, "gone"); err != nil {
+		t.Fatalf("AddWorktree failed: %v", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("failed to remove worktree directory: %v", err)
+	}
+	if err := PruneWorktrees(ctx, repo); err != nil {
+		t.Fatalf("PruneWorktrees failed: %v", err)
+	}
+	worktrees, err := ListWorktrees(ctx, repo)
+	if err != nil {
+		t.Fatalf("ListWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 1 {
+		t.Fatalf("expected stale worktree to be pruned, got %+v", worktrees)
+	}
+}
+
+func TestParseWorktrees(t *testing.T) {
+	out := `worktree /src/api
+HEAD 1111
+branch refs/heads/main
+
+worktree /src/api-fix
+HEAD 2222
+detached
+`
+	worktrees := parseWorktrees(out)
+	if len(worktrees) != 2 || worktrees[0].Path != "/src/api" || worktrees[0].Branch != "main" {
+		t.Fatalf("unexpected worktrees: %+v", worktrees)
+	}
+	if !worktrees[1].Detached || worktrees[1].Head != "2222" {
+		t.Fatalf("unexpected detached worktree: %+v", worktrees[1])
+	}
+}
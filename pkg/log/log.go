@@ -29,6 +29,12 @@ func Infof(msg string, args ...any) {
 	fmt.Fprintf(stdout, "%s %s\n", infoPrefix, fmt.Sprintf(msg, args...))
 }
 
+// Progressf reports progress on stderr, keeping stdout free for output other
+// commands consume.
+func Progressf(msg string, args ...any) {
+	fmt.Fprintf(stderr, "%s %s\n", infoPrefix, fmt.Sprintf(msg, args...))
+}
+
 func Warnf(msg string, args ...any) {
 	fmt.Fprintf(stderr, "%s %s\n", warnPrefix, fmt.Sprintf(msg, args...))
 }
@@ -40,3 +46,13 @@ func Println(args ...any) {
 func Printf(format string, args ...any) {
 	fmt.Fprintf(stdout, format, args...)
 }
+
+// Output returns the writer used for standard output, e.g. to build tables.
+func Output() io.Writer {
+	return stdout
+}
+
+// ErrorOutput returns the writer used for warnings.
+func ErrorOutput() io.Writer {
+	return stderr
+}
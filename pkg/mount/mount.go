@@ -0,0 +1,171 @@
+// Package mount tracks the sshfs mounts of SSH projects.
+package mount
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Mount is an active mount of a project.
+type Mount struct {
+	Project    string `json:"project"`
+	Source     string `json:"source"`
+	Mountpoint string `json:"mountpoint"`
+	// Auto is set for mounts made to open an editor, removed when it exits
+	Auto bool `json:"auto,omitempty"`
+}
+
+// Dir returns where mountpoints and the mount list live.
+func Dir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = filepath.Join(os.Getenv("HOME"), ".cache")
+	}
+	return filepath.Join(dir, "projects", "mounts")
+}
+
+// Mountpoint returns the directory the project is mounted on. The name is
+// made path safe and a hash of the full name keeps distinct projects, like
+// "a/b" and "a_b", from sharing a directory.
+func Mountpoint(project string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, project)
+	sum := sha256.Sum256([]byte(project))
+	return filepath.Join(Dir(), safe+"-"+hex.EncodeToString(sum[:4]))
+}
+
+func stateFile() string {
+	return filepath.Join(Dir(), "mounts.json")
+}
+
+// Load returns the tracked mounts that are still mounted, dropping the ones
+// unmounted behind our back, like after a reboot.
+func Load() ([]Mount, error) {
+	data, err := os.ReadFile(stateFile())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var mounts []Mount
+	if err := json.Unmarshal(data, &mounts); err != nil {
+		return nil, err
+	}
+
+	mounted, err := mountpoints()
+	if err != nil {
+		return nil, err
+	}
+	active := mounts[:0]
+	for _, m := range mounts {
+		if mounted[m.Mountpoint] {
+			active = append(active, m)
+		}
+	}
+	return active, nil
+}
+
+// Save writes the tracked mounts.
+func Save(mounts []Mount) error {
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(mounts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(stateFile(), data, 0o644)
+}
+
+// IsMounted reports whether something is mounted on dir.
+func IsMounted(dir string) (bool, error) {
+	mounted, err := mountpoints()
+	if err != nil {
+		return false, err
+	}
+	return mounted[filepath.Clean(dir)], nil
+}
+
+// Find returns the mount of the project, if any.
+func Find(mounts []Mount, project string) (Mount, bool) {
+	for _, m := range mounts {
+		if m.Project == project {
+			return m, true
+		}
+	}
+	return Mount{}, false
+}
+
+// Remove drops the mount of the project from the list.
+func Remove(mounts []Mount, project string) []Mount {
+	kept := mounts[:0]
+	for _, m := range mounts {
+		if m.Project != project {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// mountpoints returns the mounted directories, from /proc/self/mounts on
+// Linux and the mount command elsewhere.
+func mountpoints() (map[string]bool, error) {
+	if file, err := os.Open("/proc/self/mounts"); err == nil {
+		defer file.Close()
+		return parseProcMounts(file)
+	}
+	out, err := exec.Command("mount").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseMountOutput(string(out)), nil
+}
+
+// parseProcMounts reads the fstab like format, where spaces in paths are
+// escaped as \040.
+func parseProcMounts(r io.Reader) (map[string]bool, error) {
+	mounted := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		mounted[unescapeMount(fields[1])] = true
+	}
+	return mounted, scanner.Err()
+}
+
+// parseMountOutput reads "source on /mountpoint (options)" lines, as printed
+// by mount on macOS and BSD.
+func parseMountOutput(out string) map[string]bool {
+	mounted := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		_, rest, ok := strings.Cut(line, " on ")
+		if !ok {
+			continue
+		}
+		if i := strings.LastIndex(rest, " ("); i != -1 {
+			rest = rest[:i]
+		}
+		mounted[rest] = true
+	}
+	return mounted
+}
+
+func unescapeMount(s string) string {
+	return strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`).Replace(s)
+}
@@ -0,0 +1,78 @@
+package mount
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseProcMounts(t *testing.T) {
+	in := `proc /proc proc rw 0 0
+devbox:/srv/api /home/user/.cache/projects/mounts/api fuse.sshfs rw,nosuid 0 0
+devbox:/srv /home/user/.cache/projects/mounts/my\040app fuse.sshfs rw 0 0
+`
+	mounted, err := parseProcMounts(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, mp := range []string{"/proc", "/home/user/.cache/projects/mounts/api", "/home/user/.cache/projects/mounts/my app"} {
+		if !mounted[mp] {
+			t.Errorf("expected %q to be mounted", mp)
+		}
+	}
+}
+
+func TestParseMountOutput(t *testing.T) {
+	out := `/dev/disk3s1s1 on / (apfs, sealed, local, read-only, journaled)
+devbox:/srv/api on /Users/me/Library/Caches/projects/mounts/api (macfuse, nodev, nosuid, synchronous, mounted by me)
+`
+	mounted := parseMountOutput(out)
+	if !mounted["/"] || !mounted["/Users/me/Library/Caches/projects/mounts/api"] {
+		t.Fatalf("unexpected mounts: %v", mounted)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	mounts := []Mount{{Project: "gone", Source: "devbox:/srv", Mountpoint: "/nonexistent/mountpoint"}}
+	if err := Save(mounts); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded) != 0 {
+		t.Fatalf("expected stale mounts to be dropped, got %v", loaded)
+	}
+
+	mounts = []Mount{{Project: "api"}, {Project: "web"}}
+	if _, ok := Find(mounts, "web"); !ok {
+		t.Fatal("expected to find web")
+	}
+	mounts = Remove(mounts, "api")
+	if len(mounts) != 1 || mounts[0].Project != "web" {
+		t.Fatalf("unexpected mounts: %v", mounts)
+	}
+}
+
+func TestMountpoint(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/cache")
+	t.Setenv("HOME", "/home/user")
+
+	api := Mountpoint("my api")
+	if !strings.HasPrefix(api, Dir()+"/my_api-") || strings.ContainsAny(strings.TrimPrefix(api, Dir()+"/"), " /") {
+		t.Fatalf("unexpected mountpoint: %s", api)
+	}
+	if Mountpoint("my api") != api {
+		t.Fatal("expected the mountpoint to be stable")
+	}
+	if Mountpoint("a/b") == Mountpoint("a_b") {
+		t.Fatal("expected distinct projects to get distinct mountpoints")
+	}
+	if mp := Mountpoint(".."); filepath.Dir(mp) != Dir() {
+		t.Fatalf("expected mountpoint inside %s, got %s", Dir(), mp)
+	}
+}
@@ -0,0 +1,84 @@
+package project
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Container identifies the container of a container project. Dev containers
+// only know the host folder they were built from, the container is found by
+// the label the devcontainer CLI sets on it.
+type Container struct {
+	Name        string
+	LocalFolder string
+}
+
+// ContainerInfo returns the container and the directory inside it. It
+// understands VS Code attached-container and dev-container uris and
+// docker://<container>/path.
+func (p *Project) ContainerInfo() (Container, string, error) {
+	if p.ProjectType != ProjectTypeContainer {
+		return Container{}, "", fmt.Errorf("project is not container type")
+	}
+
+	dir := p.Path
+	if p.IsWorkspace {
+		if i := strings.LastIndex(dir, "/"); i > 0 {
+			dir = dir[:i]
+		}
+	}
+
+	if p.Scheme == "docker" {
+		if p.Domain == "" {
+			return Container{}, "", fmt.Errorf("invalid container path format: missing container in %q", p.RootPath)
+		}
+		return Container{Name: p.Domain}, dir, nil
+	}
+
+	kind, encoded, ok := strings.Cut(p.Domain, "+")
+	if !ok {
+		return Container{}, "", fmt.Errorf("invalid container path format: missing container in %q", p.RootPath)
+	}
+	data, err := hex.DecodeString(encoded)
+	if err != nil {
+		return Container{}, "", fmt.Errorf("invalid container in %q: %w", p.RootPath, err)
+	}
+
+	var config struct {
+		ContainerName string `json:"containerName"`
+		HostPath      string `json:"hostPath"`
+	}
+	if kind == "dev-container" && !strings.HasPrefix(string(data), "{") {
+		// older dev-container uris encode the host folder alone
+		return Container{LocalFolder: string(data)}, dir, nil
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Container{}, "", fmt.Errorf("invalid container in %q: %w", p.RootPath, err)
+	}
+	c := Container{
+		Name:        strings.TrimPrefix(config.ContainerName, "/"),
+		LocalFolder: config.HostPath,
+	}
+	if c.Name == "" && c.LocalFolder == "" {
+		return Container{}, "", fmt.Errorf("invalid container in %q: no container name or host path", p.RootPath)
+	}
+	return c, dir, nil
+}
+
+// VSCodeURI returns the uri VS Code based editors open, docker:// projects
+// become attached-container uris.
+func (p *Project) VSCodeURI() string {
+	if p.ProjectType != ProjectTypeContainer || p.Scheme != "docker" {
+		return p.RootPath
+	}
+	return AttachedContainerURI(p.Domain, p.Path)
+}
+
+// AttachedContainerURI builds the VS Code uri of a path in a running
+// container.
+func AttachedContainerURI(container, path string) string {
+	data, _ := json.Marshal(map[string]string{"containerName": "/" + container})
+	return "vscode-remote://attached-container+" + hex.EncodeToString(data) + path
+}
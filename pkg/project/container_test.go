@@ -0,0 +1,63 @@
+package project
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func containerProject(root string) *Project {
+	p := &Project{Name: "app", RootPath: root}
+	p.Init()
+	return p
+}
+
+func TestContainerInfo(t *testing.T) {
+	attached := AttachedContainerURI("web", "/app")
+	devcontainer := "vscode-remote://dev-container+" + hex.EncodeToString([]byte(`{"hostPath":"/home/user/app"}`)) + "/workspaces/app"
+	legacy := "vscode-remote://dev-container+" + hex.EncodeToString([]byte("/home/user/app")) + "/workspaces/app"
+
+	tests := []struct {
+		root      string
+		container Container
+		dir       string
+	}{
+		{"docker://web/srv/app", Container{Name: "web"}, "/srv/app"},
+		{attached, Container{Name: "web"}, "/app"},
+		{devcontainer, Container{LocalFolder: "/home/user/app"}, "/workspaces/app"},
+		{legacy, Container{LocalFolder: "/home/user/app"}, "/workspaces/app"},
+		{"docker://web/srv/app.code-workspace", Container{Name: "web"}, "/srv"},
+	}
+	for _, tt := range tests {
+		p := containerProject(tt.root)
+		if p.ProjectType != ProjectTypeContainer {
+			t.Fatalf("%s: expected container type, got %s", tt.root, p.ProjectType)
+		}
+		c, dir, err := p.ContainerInfo()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.root, err)
+		}
+		if c != tt.container || dir != tt.dir {
+			t.Errorf("%s: got %+v %s, want %+v %s", tt.root, c, dir, tt.container, tt.dir)
+		}
+		if err := p.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation error: %v", tt.root, err)
+		}
+	}
+
+	if err := containerProject("vscode-remote://attached-container+zz/app").Validate(); err == nil {
+		t.Fatal("expected error for invalid container encoding")
+	}
+}
+
+func TestVSCodeURI(t *testing.T) {
+	p := containerProject("docker://web/srv/app")
+	expected := "vscode-remote://attached-container+" + hex.EncodeToString([]byte(`{"containerName":"/web"}`)) + "/srv/app"
+	if got := p.VSCodeURI(); got != expected {
+		t.Fatalf("got %s, want %s", got, expected)
+	}
+
+	local := containerProject("/src/app")
+	if local.VSCodeURI() != "/src/app" {
+		t.Fatal("expected local projects to keep their path")
+	}
+}
@@ -0,0 +1,235 @@
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Environment returns the variables declared by the project as KEY=VALUE
+// pairs. Files listed in EnvFiles are loaded first, in order, and the Env map
+// is applied last so it overrides them. Values may reference previously
+// defined variables or the current environment using $VAR or ${VAR}.
+func (p *Project) Environment() ([]string, error) {
+	return p.EnvironmentWith(nil)
+}
+
+// EnvironmentWith returns the project environment with extra applied on top,
+// e.g. the variables of a task.
+func (p *Project) EnvironmentWith(extra map[string]string) ([]string, error) {
+	return p.environment(extra, false)
+}
+
+// ShellEnvironmentWith returns the project environment as KEY=WORD shell
+// assignments for a remote shell. References to variables the project does
+// not declare, like $HOME or $PATH, are kept for the remote shell to expand
+// instead of taking the local values.
+func (p *Project) ShellEnvironmentWith(extra map[string]string) ([]string, error) {
+	return p.environment(extra, true)
+}
+
+func (p *Project) environment(extra map[string]string, remote bool) ([]string, error) {
+	var (
+		values = map[string]envValue{}
+		keys   []string
+	)
+	set := func(key string, value envValue) {
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = value
+	}
+	expand := func(s string) envValue {
+		return expandEnv(s, func(key string) envValue {
+			if v, ok := values[key]; ok {
+				return v
+			}
+			if remote {
+				return envValue{{text: key, ref: true}}
+			}
+			return envValue{{text: os.Getenv(key)}}
+		})
+	}
+
+	for _, name := range p.EnvFiles {
+		file, err := p.envFilePath(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := loadEnvFile(file, expand, set); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, vars := range []map[string]string{p.Env, extra} {
+		names := make([]string, 0, len(vars))
+		for key := range vars {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		for _, key := range names {
+			set(key, expand(vars[key]))
+		}
+	}
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if remote {
+			env = append(env, key+"="+values[key].shellWord())
+		} else {
+			env = append(env, key+"="+values[key].String())
+		}
+	}
+	return env, nil
+}
+
+// envValue is an expanded value, made of literal text and references left
+// for a remote shell.
+type envValue []envPart
+
+type envPart struct {
+	text string
+	ref  bool
+}
+
+func (v envValue) String() string {
+	var b strings.Builder
+	for _, part := range v {
+		if part.ref {
+			b.WriteString("${" + part.text + "}")
+		} else {
+			b.WriteString(part.text)
+		}
+	}
+	return b.String()
+}
+
+// shellWord quotes the literal text and leaves the references in double
+// quotes, so only they are expanded by the shell.
+func (v envValue) shellWord() string {
+	if len(v) == 0 {
+		return "''"
+	}
+	var b strings.Builder
+	for _, part := range v {
+		if part.ref {
+			b.WriteString(`"${` + part.text + `}"`)
+		} else {
+			b.WriteString("'" + strings.ReplaceAll(part.text, "'", `'\''`) + "'")
+		}
+	}
+	return b.String()
+}
+
+// expandEnv replaces $VAR and ${VAR} in s using lookup, like os.Expand.
+func expandEnv(s string, lookup func(string) envValue) envValue {
+	var (
+		value envValue
+		text  strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			value = append(value, envPart{text: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			text.WriteByte(s[i])
+			continue
+		}
+		name, width := envName(s[i+1:])
+		if width == 0 {
+			text.WriteByte(s[i])
+			continue
+		}
+		flush()
+		value = append(value, lookup(name)...)
+		i += width
+	}
+	flush()
+	return value
+}
+
+// envName returns the variable name at the start of s and the bytes it takes,
+// zero when s does not start with a name.
+func envName(s string) (string, int) {
+	if s[0] == '{' {
+		if end := strings.IndexByte(s, '}'); end > 1 {
+			return s[1:end], end + 1
+		}
+		return "", 0
+	}
+	n := 0
+	for n < len(s) && (s[n] == '_' || s[n] >= 'a' && s[n] <= 'z' || s[n] >= 'A' && s[n] <= 'Z' || n > 0 && s[n] >= '0' && s[n] <= '9') {
+		n++
+	}
+	return s[:n], n
+}
+
+// envFilePath resolves relative env files against the local project directory.
+// Env files are always read on the local machine, so remote projects must use
+// absolute or home relative paths.
+func (p *Project) envFilePath(name string) (string, error) {
+	if strings.HasPrefix(name, "~/") {
+		return expandHome(name), nil
+	}
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	if p.ProjectType != ProjectTypeLocal {
+		return "", fmt.Errorf("env file %q must be an absolute path for %s projects", name, p.ProjectType)
+	}
+	dir := p.RootPath
+	if p.IsWorkspace {
+		dir = filepath.Dir(dir)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+func expandHome(name string) string {
+	if strings.HasPrefix(name, "~/") {
+		return filepath.Join(os.Getenv("HOME"), name[2:])
+	}
+	return name
+}
+
+func loadEnvFile(name string, expand func(string) envValue, set func(key string, value envValue)) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("failed to load env file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%s:%d: invalid line, expected KEY=VALUE", name, n)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			set(key, envValue{{text: value[1 : len(value)-1]}})
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			set(key, expand(value[1:len(value)-1]))
+		default:
+			if i := strings.Index(value, " #"); i > -1 {
+				value = strings.TrimSpace(value[:i])
+			}
+			set(key, expand(value))
+		}
+	}
+	return scanner.Err()
+}
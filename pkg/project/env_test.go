@@ -0,0 +1,104 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	content := `# comment
+export DB_HOST=localhost
+DB_PORT=5432 # inline comment
+DB_URL="postgres://${DB_HOST}:${DB_PORT}"
+RAW='$DB_HOST'
+`
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+	t.Setenv("PROJECTS_TEST_USER", "alice")
+
+	p := &Project{
+		RootPath:    dir,
+		ProjectType: ProjectTypeLocal,
+		EnvFiles:    []string{".env"},
+		Env: map[string]string{
+			"DB_PORT": "6543",
+			"OWNER":   "$PROJECTS_TEST_USER",
+		},
+	}
+
+	env, err := p.Environment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"DB_HOST=localhost",
+		"DB_PORT=6543",
+		"DB_URL=postgres://localhost:5432",
+		"RAW=$DB_HOST",
+		"OWNER=alice",
+	}
+	if strings.Join(env, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected environment:\n%s", strings.Join(env, "\n"))
+	}
+}
+
+func TestProjectShellEnvironment(t *testing.T) {
+	t.Setenv("HOME", "/home/local")
+	p := &Project{Env: map[string]string{
+		"BIN":  "$HOME/bin",
+		"PATH": "$BIN:$PATH",
+		"NAME": "it's $ 5",
+	}}
+
+	env, err := p.ShellEnvironmentWith(map[string]string{"EMPTY": ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		`BIN="${HOME}"'/bin'`,
+		`NAME='it'\''s $ 5'`,
+		`PATH="${HOME}"'/bin'':'"${PATH}"`,
+		`EMPTY=''`,
+	}
+	if strings.Join(env, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected environment:\n%s", strings.Join(env, "\n"))
+	}
+}
+
+func TestProjectEnvironmentWith(t *testing.T) {
+	p := &Project{Env: map[string]string{"A": "1", "B": "2"}}
+
+	env, err := p.EnvironmentWith(map[string]string{"B": "${A}0", "C": "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(env, " ") != "A=1 B=10 C=3" {
+		t.Fatalf("unexpected environment: %v", env)
+	}
+}
+
+func TestProjectEnvironmentErrors(t *testing.T) {
+	dir := t.TempDir()
+	p := &Project{RootPath: dir, ProjectType: ProjectTypeLocal, EnvFiles: []string{"missing.env"}}
+	if _, err := p.Environment(); err == nil {
+		t.Fatalf("expected error for missing env file")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "bad.env"), []byte("NOVALUE\n"), 0o644); err != nil {
+		t.Fatalf("failed to write env file: %v", err)
+	}
+	p.EnvFiles = []string{"bad.env"}
+	if _, err := p.Environment(); err == nil {
+		t.Fatalf("expected error for malformed env file")
+	}
+
+	remote := &Project{RootPath: "/srv/api", ProjectType: ProjectTypeSSH, EnvFiles: []string{".env"}}
+	if _, err := remote.Environment(); err == nil {
+		t.Fatalf("expected error for relative env file on remote project")
+	}
+}
@@ -0,0 +1,160 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NotFoundError is returned when no project matches a name. Suggestions holds
+// the names of projects that are close enough to be a typo.
+type NotFoundError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *NotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("project '%s' not found", e.Name)
+	}
+	return fmt.Sprintf("project '%s' not found, did you mean: %s?", e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrProjectNotFound
+}
+
+type candidate struct {
+	index int
+	score int
+}
+
+// Match resolves name using, in order, a unique prefix of a name or alias and
+// a fuzzy (subsequence) match. When more than one project matches an
+// AmbiguousError is returned with the matches ordered by relevance.
+func (projects Projects) Match(name string) (*Project, int, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, -1, ErrProjectNotFound
+	}
+
+	for _, scorer := range []func(string, string) int{prefixScore, fuzzyScore} {
+		candidates := projects.rank(name, scorer)
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			i := candidates[0].index
+			return &projects[i], i, nil
+		}
+		err := &AmbiguousError{Name: name}
+		for _, c := range candidates {
+			err.Matches = append(err.Matches, projects[c.index].Name)
+		}
+		return nil, -1, err
+	}
+
+	return nil, -1, &NotFoundError{Name: name, Suggestions: projects.Suggest(name, 3)}
+}
+
+// Suggest returns up to limit project names within a small edit distance of name.
+func (projects Projects) Suggest(name string, limit int) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	candidates := projects.rank(name, func(query, value string) int {
+		d := levenshtein(query, value)
+		if d > maxDistance {
+			return 0
+		}
+		return maxDistance - d + 1
+	})
+
+	var suggestions []string
+	for _, c := range candidates {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, projects[c.index].Name)
+	}
+	return suggestions
+}
+
+// rank scores every project using the best score among its names and returns
+// the ones with a positive score, best first.
+func (projects Projects) rank(query string, scorer func(query, value string) int) []candidate {
+	var candidates []candidate
+	for i := range projects {
+		best := 0
+		for _, n := range projects[i].Names() {
+			if score := scorer(query, strings.ToLower(n)); score > best {
+				best = score
+			}
+		}
+		if best > 0 {
+			candidates = append(candidates, candidate{index: i, score: best})
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].score != candidates[b].score {
+			return candidates[a].score > candidates[b].score
+		}
+		return projects[candidates[a].index].Name < projects[candidates[b].index].Name
+	})
+	return candidates
+}
+
+func prefixScore(query, value string) int {
+	if !strings.HasPrefix(value, query) {
+		return 0
+	}
+	// shorter names are a closer match for the same prefix
+	return 1000 - (len(value) - len(query))
+}
+
+// fuzzyScore matches query as a subsequence of value, rewarding consecutive
+// characters and characters at the start of a word.
+func fuzzyScore(query, value string) int {
+	score, qi, prevMatch := 0, 0, -2
+	for vi := 0; vi < len(value) && qi < len(query); vi++ {
+		if value[vi] != query[qi] {
+			continue
+		}
+		score += 1
+		if vi == prevMatch+1 {
+			score += 2
+		}
+		if vi == 0 || strings.ContainsRune("-_. /", rune(value[vi-1])) {
+			score += 3
+		}
+		prevMatch = vi
+		qi++
+	}
+	if qi < len(query) {
+		return 0
+	}
+	return score
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
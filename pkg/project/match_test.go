@@ -0,0 +1,73 @@
+package project
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProjectsMatchPrefix(t *testing.T) {
+	projects := Projects{
+		{Name: "frontend-web"},
+		{Name: "backend-api", Aliases: Aliases{"api"}},
+		{Name: "apps"},
+	}
+
+	if p, _, err := projects.Find("fro", ""); err != nil || p.Name != "frontend-web" {
+		t.Fatalf("expected unique prefix to match, got %v %v", p, err)
+	}
+
+	_, _, err := projects.Find("ap", "")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected ambiguous error for shared prefix, got %v", err)
+	}
+	if len(ambiguous.Matches) != 2 || ambiguous.Matches[0] != "backend-api" {
+		t.Fatalf("expected shorter match first, got %v", ambiguous.Matches)
+	}
+}
+
+func TestProjectsMatchFuzzy(t *testing.T) {
+	projects := Projects{
+		{Name: "frontend-web"},
+		{Name: "backend-api"},
+	}
+
+	if p, _, err := projects.Find("fweb", ""); err != nil || p.Name != "frontend-web" {
+		t.Fatalf("expected fuzzy match, got %v %v", p, err)
+	}
+
+	_, _, err := projects.Find("end", "")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+		t.Fatalf("expected ambiguous fuzzy match, got %v", err)
+	}
+}
+
+func TestProjectsMatchSuggestions(t *testing.T) {
+	projects := Projects{
+		{Name: "frontend"},
+		{Name: "backend"},
+	}
+
+	_, _, err := projects.Find("frontedn", "")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if !errors.Is(err, ErrProjectNotFound) {
+		t.Fatalf("expected error to match ErrProjectNotFound")
+	}
+	if len(notFound.Suggestions) != 1 || notFound.Suggestions[0] != "frontend" {
+		t.Fatalf("unexpected suggestions: %v", notFound.Suggestions)
+	}
+}
+
+func TestProjectsFindDoesNotFuzzyMatchPwd(t *testing.T) {
+	projects := Projects{
+		{Name: "frontend-web", RootPath: "/src/frontend-web"},
+	}
+
+	if p, _, err := projects.Find("fro", "/tmp/fro"); err == nil {
+		t.Fatalf("expected no match when resolving from pwd, got %v", p)
+	}
+}
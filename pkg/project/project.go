@@ -1,24 +1,29 @@
 package project
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"text/template"
 
 	"github.com/filipenos/projects/pkg/config"
 	"github.com/filipenos/projects/pkg/file"
 	"github.com/filipenos/projects/pkg/path"
+	"github.com/filipenos/projects/pkg/task"
+	"github.com/filipenos/projects/pkg/workspace"
 )
 
 type ProjectType string
 
 const (
-	ProjectTypeLocal  ProjectType = "local"
-	ProjectTypeSSH    ProjectType = "ssh"
-	ProjectTypeWSL    ProjectType = "wsl"
-	ProjectTypeTunnel ProjectType = "tunnel"
+	ProjectTypeLocal     ProjectType = "local"
+	ProjectTypeSSH       ProjectType = "ssh"
+	ProjectTypeWSL       ProjectType = "wsl"
+	ProjectTypeTunnel    ProjectType = "tunnel"
+	ProjectTypeContainer ProjectType = "container"
 )
 
 func ParseProjectType(typ string) ProjectType {
@@ -32,6 +37,8 @@ func ParseProjectType(typ string) ProjectType {
 			return ProjectTypeSSH
 		case "tunnel":
 			return ProjectTypeTunnel
+		case "dev-container", "attached-container":
+			return ProjectTypeContainer
 		default:
 			return ProjectType(typ[:i])
 		}
@@ -63,20 +70,120 @@ func parseURL(input string) (string, string, string) {
 }
 
 var (
-	ErrNameRequired = fmt.Errorf("name is required")
-	ErrPathRequired = fmt.Errorf("path is required")
-	ErrPathNoExist  = fmt.Errorf("path is no exists")
+	ErrNameRequired    = fmt.Errorf("name is required")
+	ErrPathRequired    = fmt.Errorf("path is required")
+	ErrPathNoExist     = fmt.Errorf("path is no exists")
+	ErrProjectNotFound = fmt.Errorf("project not found")
 )
 
+// ReservedNames can't name a project or alias, they are the subcommands of
+// projects session and would hide the project.
+var ReservedNames = []string{"ls", "list", "kill", "switch"}
+
+// AmbiguousError is returned when a name matches more than one project.
+type AmbiguousError struct {
+	Name    string
+	Matches []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("'%s' is ambiguous, matches: %s", e.Name, strings.Join(e.Matches, ", "))
+}
+
+// Aliases holds the alternative names of a project. It is stored as a plain
+// string when there is a single alias to keep old config files compatible.
+type Aliases []string
+
+func (a Aliases) Contains(name string) bool {
+	for _, alias := range a {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Aliases) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Aliases) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*a = nil
+		return nil
+	}
+	if data[0] == '"' {
+		var alias string
+		if err := json.Unmarshal(data, &alias); err != nil {
+			return err
+		}
+		*a = ParseAliases(alias)
+		return nil
+	}
+	var aliases []string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return err
+	}
+	*a = nil
+	for _, alias := range aliases {
+		*a = append(*a, ParseAliases(alias)...)
+	}
+	return nil
+}
+
+// ParseAliases splits a comma separated list of aliases.
+func ParseAliases(value string) Aliases {
+	var aliases Aliases
+	for _, alias := range strings.Split(value, ",") {
+		if alias = strings.TrimSpace(alias); alias != "" && !aliases.Contains(alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// Window describes a terminal window created when a session starts. Dirs are
+// relative to the project directory and Layout is a tmux layout name like
+// even-horizontal, main-vertical or tiled.
+type Window struct {
+	Name    string `json:"name,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+	Command string `json:"command,omitempty"`
+	Layout  string `json:"layout,omitempty"`
+	Panes   []Pane `json:"panes,omitempty"`
+}
+
+// Pane is an extra split of a window. The first pane is the window itself.
+type Pane struct {
+	Dir     string `json:"dir,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
 // Project represent then project
 type Project struct {
 	Name     string   `json:"name,omitempty"`
-	Alias    string   `json:"alias,omitempty"`
+	Aliases  Aliases  `json:"alias,omitempty"`
 	RootPath string   `json:"rootPath,omitempty"`
 	Group    string   `json:"group,omitempty"`
 	Enabled  bool     `json:"enabled,omitempty"`
 	SCM      string   `json:"scm,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
+	Parent   string   `json:"parent,omitempty"`
+
+	Env      map[string]string `json:"env,omitempty"`
+	EnvFiles []string          `json:"envFiles,omitempty"`
+
+	Tasks map[string]task.Task `json:"tasks,omitempty"`
+
+	Windows      []Window `json:"windows,omitempty"`
+	ZellijLayout string   `json:"zellijLayout,omitempty"`
+
+	SSH           *SSHOptions `json:"ssh,omitempty"`
+	TunnelCommand string      `json:"tunnelCommand,omitempty"`
 
 	Scheme string `json:"-"`
 	Domain string `json:"-"`
@@ -94,10 +201,22 @@ func (p *Project) SSHInfo() (host string, remotePath string, err error) {
 	if p.ProjectType != ProjectTypeSSH {
 		return "", "", fmt.Errorf("project is not SSH type")
 	}
+	return p.remoteInfo()
+}
+
+// TunnelInfo returns the tunnel name and the remote directory of a VS Code
+// tunnel project.
+func (p *Project) TunnelInfo() (tunnel string, remotePath string, err error) {
+	if p.ProjectType != ProjectTypeTunnel {
+		return "", "", fmt.Errorf("project is not tunnel type")
+	}
+	return p.remoteInfo()
+}
 
+func (p *Project) remoteInfo() (host string, remotePath string, err error) {
 	i := strings.Index(p.Domain, "+")
 	if i == -1 {
-		return "", "", fmt.Errorf("invalid SSH path format: missing host in %q", p.RootPath)
+		return "", "", fmt.Errorf("invalid %s path format: missing host in %q", p.ProjectType, p.RootPath)
 	}
 	host = p.Domain[i+1:]
 	remotePath = p.Path
@@ -112,6 +231,22 @@ func (p *Project) SSHInfo() (host string, remotePath string, err error) {
 	return host, remotePath, nil
 }
 
+// Init fills the fields derived from RootPath, done by Load for saved projects.
+func (p *Project) Init() {
+	p.Scheme, p.Domain, p.Path = parseURL(p.RootPath)
+	if p.Scheme == "docker" {
+		p.ProjectType = ProjectTypeContainer
+		p.ValidPath = true
+	} else if p.Scheme != "" {
+		p.ProjectType = ParseProjectType(p.Domain)
+		p.ValidPath = true
+	} else {
+		p.ProjectType = ProjectTypeLocal
+		p.ValidPath = path.Exist(p.RootPath)
+	}
+	p.IsWorkspace = strings.HasSuffix(p.RootPath, ".code-workspace")
+}
+
 func (p *Project) Validate() error {
 	if p.Name == "" {
 		return ErrNameRequired
@@ -124,7 +259,13 @@ func (p *Project) Validate() error {
 		if !path.Exist(p.RootPath) {
 			return fmt.Errorf("path '%s' of project '%s' not exists", p.RootPath, p.Name)
 		}
-	case ProjectTypeSSH, ProjectTypeWSL, ProjectTypeTunnel:
+	case ProjectTypeSSH:
+		_, _, err := p.SSHInfo()
+		return err
+	case ProjectTypeContainer:
+		_, _, err := p.ContainerInfo()
+		return err
+	case ProjectTypeWSL, ProjectTypeTunnel:
 	default:
 		return fmt.Errorf("invalid project type: %s", p.ProjectType)
 	}
@@ -137,14 +278,78 @@ func (projects Projects) Len() int           { return len(projects) }
 func (projects Projects) Swap(i, j int)      { projects[i], projects[j] = projects[j], projects[i] }
 func (projects Projects) Less(i, j int) bool { return projects[i].Name < projects[j].Name }
 
+// HasTag reports whether the project is tagged with tag.
+func (p *Project) HasTag(tag string) bool {
+	for _, t := range p.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the name followed by the aliases of the project.
+func (p *Project) Names() []string {
+	return append([]string{p.Name}, p.Aliases...)
+}
+
 func (projects Projects) Get(name string) (*Project, int) {
+	p, i, _ := projects.Lookup(name)
+	return p, i
+}
+
+// Lookup returns the project whose name or alias is exactly name. Names take
+// precedence over aliases; an alias shared by several projects is reported
+// as an AmbiguousError.
+func (projects Projects) Lookup(name string) (*Project, int, error) {
 	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, -1, ErrProjectNotFound
+	}
 	for i := range projects {
-		if projects[i].Name == name || projects[i].Alias == name {
-			return &projects[i], i
+		if projects[i].Name == name {
+			return &projects[i], i, nil
 		}
 	}
-	return nil, -1
+	var matches []int
+	for i := range projects {
+		if projects[i].Aliases.Contains(name) {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, -1, ErrProjectNotFound
+	case 1:
+		return &projects[matches[0]], matches[0], nil
+	}
+	err := &AmbiguousError{Name: name}
+	for _, i := range matches {
+		err.Matches = append(err.Matches, projects[i].Name)
+	}
+	return nil, -1, err
+}
+
+// CheckConflicts reports whether the name or any alias of p is already used
+// by another project. The project at position skip is ignored, use -1 to
+// check every project.
+func (projects Projects) CheckConflicts(p *Project, skip int) error {
+	for _, name := range p.Names() {
+		for _, reserved := range ReservedNames {
+			if name == reserved {
+				return fmt.Errorf("'%s' is reserved for a session subcommand, choose another name", name)
+			}
+		}
+		for i := range projects {
+			if i == skip {
+				continue
+			}
+			if projects[i].Name == name || projects[i].Aliases.Contains(name) {
+				return fmt.Errorf("'%s' is already used by project '%s'", name, projects[i].Name)
+			}
+		}
+	}
+	return nil
 }
 
 func (projects Projects) GetByPath(path string) (*Project, int) {
@@ -157,36 +362,83 @@ func (projects Projects) GetByPath(path string) (*Project, int) {
 	return nil, -1
 }
 
-func (projects Projects) Find(name, path string) (*Project, int) {
-	var (
-		project *Project
-		pos     int
-	)
-	if name != "" {
-		project, pos = projects.Get(name)
-		if project != nil {
-			return project, pos
+// Resolve returns the local project containing dir, choosing the one with the
+// longest matching root when projects are nested. Folders of workspace
+// projects count as roots of the workspace.
+func (projects Projects) Resolve(dir string) (*Project, int) {
+	dir = filepath.Clean(strings.TrimSpace(dir))
+	best, bestLen := -1, -1
+	for i := range projects {
+		if projects[i].ProjectType != ProjectTypeLocal && projects[i].ProjectType != "" {
+			continue
+		}
+		for _, root := range projects[i].localRoots() {
+			if len(root) > bestLen && isSubPath(root, dir) {
+				best, bestLen = i, len(root)
+			}
 		}
 	}
-	if path != "" {
-		project, pos = projects.GetByPath(path)
-		if project != nil {
-			return project, pos
+	if best == -1 {
+		return nil, -1
+	}
+	return &projects[best], best
+}
+
+func (p *Project) localRoots() []string {
+	if !p.IsWorkspace {
+		return []string{filepath.Clean(p.RootPath)}
+	}
+	roots := []string{filepath.Dir(p.RootPath)}
+	if ws, err := workspace.Load(p.RootPath); err == nil {
+		for _, folder := range ws.FoldersPath() {
+			roots = append(roots, filepath.Clean(folder))
 		}
+	}
+	return roots
+}
 
+func isSubPath(root, dir string) bool {
+	if root == "" || root == "." {
+		return false
+	}
+	if root == dir || root == "/" {
+		return true
+	}
+	return strings.HasPrefix(dir, root+"/")
+}
+
+// Find resolves a project by exact name or alias. When only a name is given it
+// falls back to prefix and fuzzy matching; when a path is given the project
+// containing it wins, then the names of its directories are tried.
+func (projects Projects) Find(name, path string) (*Project, int, error) {
+	if path != "" {
+		if project, pos := projects.Resolve(path); project != nil {
+			return project, pos, nil
+		}
+	}
+	if name != "" {
+		project, pos, err := projects.Lookup(name)
+		if err != ErrProjectNotFound {
+			return project, pos, err
+		}
+		if path == "" {
+			return projects.Match(name)
+		}
+	}
+	if path != "" {
 		paths := strings.Split(path, "/")
 		for i := len(paths) - 1; i >= 0; i-- {
 			namePath := strings.TrimSpace(paths[i])
 			if namePath == "" {
 				continue
 			}
-			project, pos = projects.Get(namePath)
-			if project != nil {
-				return project, pos
+			project, pos, err := projects.Lookup(namePath)
+			if err != ErrProjectNotFound {
+				return project, pos, err
 			}
 		}
 	}
-	return nil, -1
+	return nil, -1, ErrProjectNotFound
 }
 
 // Save save the current projects on conf file
@@ -218,20 +470,7 @@ func Load(s config.Config) (Projects, error) {
 		if strings.Contains(p.RootPath, "~") {
 			projects[i].RootPath = strings.Replace(p.RootPath, "~", os.Getenv("HOME"), 1)
 		}
-
-		projects[i].Scheme, projects[i].Domain, projects[i].Path = parseURL(p.RootPath)
-		if projects[i].Scheme != "" {
-			projects[i].ProjectType = ParseProjectType(projects[i].Domain)
-			projects[i].ValidPath = true
-		} else {
-			projects[i].ProjectType = ProjectTypeLocal
-			projects[i].ValidPath = path.Exist(p.RootPath)
-		}
-
-		if strings.HasSuffix(p.RootPath, ".code-workspace") {
-			projects[i].IsWorkspace = true
-		}
-
+		projects[i].Init()
 	}
 
 	return projects, nil
@@ -245,11 +484,12 @@ func EditProject(p *Project) (*Project, error) {
 	defer tmp.Remove()
 
 	d := `name={{.Name}}
+alias={{join .Aliases ","}}
 path={{.Path}}
 group={{.Group}}
 enabled={{.Enabled}}`
 
-	tmpl := template.Must(template.New("editor").Parse(d))
+	tmpl := template.Must(template.New("editor").Funcs(template.FuncMap{"join": strings.Join}).Parse(d))
 	if err := tmpl.Execute(tmp, p); err != nil {
 		return nil, err
 	}
@@ -280,6 +520,8 @@ func ParseContent(data []byte) *Project {
 		switch values[0] {
 		case "name":
 			p.Name = v
+		case "alias":
+			p.Aliases = ParseAliases(v)
 		case "path":
 			p.RootPath = v
 		case "group":
@@ -290,3 +532,42 @@ func ParseContent(data []byte) *Project {
 	}
 	return p
 }
+
+// Rename changes the name of the project identified by oldName, updating the
+// children that reference it. When keepAlias is true the old name is kept as
+// alias so existing scripts keep working.
+func (projects Projects) Rename(oldName, newName string, keepAlias bool) (*Project, error) {
+	oldName, newName = strings.TrimSpace(oldName), strings.TrimSpace(newName)
+	if oldName == "" || newName == "" {
+		return nil, ErrNameRequired
+	}
+
+	p, index, err := projects.Lookup(oldName)
+	if err != nil {
+		if err == ErrProjectNotFound {
+			return nil, fmt.Errorf("project '%s' not found", oldName)
+		}
+		return nil, err
+	}
+
+	renamed := Project{Name: newName}
+	for _, alias := range p.Aliases {
+		if alias != newName {
+			renamed.Aliases = append(renamed.Aliases, alias)
+		}
+	}
+	if keepAlias && !renamed.Aliases.Contains(p.Name) {
+		renamed.Aliases = append(renamed.Aliases, p.Name)
+	}
+	if err := projects.CheckConflicts(&renamed, index); err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		if projects[i].Parent == p.Name {
+			projects[i].Parent = renamed.Name
+		}
+	}
+	p.Name, p.Aliases = renamed.Name, renamed.Aliases
+	return p, nil
+}
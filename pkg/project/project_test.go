@@ -1,6 +1,8 @@
 package project
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -66,15 +68,15 @@ func TestProjectsFind(t *testing.T) {
 		{Name: "beta", RootPath: "/tmp/beta"},
 	}
 
-	if p, _ := projects.Find("alpha", ""); p == nil || p.Name != "alpha" {
+	if p, _, _ := projects.Find("alpha", ""); p == nil || p.Name != "alpha" {
 		t.Fatalf("expected to find project by name")
 	}
 
-	if p, _ := projects.Find("", "/tmp/beta"); p == nil || p.Name != "beta" {
+	if p, _, _ := projects.Find("", "/tmp/beta"); p == nil || p.Name != "beta" {
 		t.Fatalf("expected to find project by path")
 	}
 
-	if p, _ := projects.Find("", "/tmp/beta/subdir"); p == nil || p.Name != "beta" {
+	if p, _, _ := projects.Find("", "/tmp/beta/subdir"); p == nil || p.Name != "beta" {
 		t.Fatalf("expected to resolve by parent path")
 	}
 }
@@ -128,3 +130,153 @@ func TestParseContentDefaultsEnabledToTrue(t *testing.T) {
 		t.Fatalf("expected enabled=true by default: %+v", p)
 	}
 }
+
+func TestProjectsRename(t *testing.T) {
+	projects := Projects{
+		{Name: "alpha", Aliases: Aliases{"a"}, RootPath: "/tmp/alpha"},
+		{Name: "beta", RootPath: "/tmp/beta"},
+		{Name: "alpha-fix", RootPath: "/tmp/alpha-fix", Parent: "alpha"},
+	}
+
+	p, err := projects.Rename("a", "gamma", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name != "gamma" || len(p.Aliases) != 1 || p.Aliases[0] != "a" || projects[0].Name != "gamma" {
+		t.Fatalf("unexpected rename result: %+v", projects[0])
+	}
+	if projects[2].Parent != "gamma" {
+		t.Fatalf("expected child to follow the rename: %+v", projects[2])
+	}
+
+	if _, err := projects.Rename("gamma", "delta", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if projects[0].Name != "delta" || !projects[0].Aliases.Contains("gamma") || !projects[0].Aliases.Contains("a") {
+		t.Fatalf("expected old name to be kept as alias: %+v", projects[0])
+	}
+
+	if _, err := projects.Rename("delta", "beta", false); err == nil {
+		t.Fatalf("expected error when new name collides")
+	}
+	if _, err := projects.Rename("missing", "other", false); err == nil {
+		t.Fatalf("expected error when project is missing")
+	}
+}
+
+func TestAliasesJSONCompatibility(t *testing.T) {
+	var p Project
+	if err := json.Unmarshal([]byte(`{"name":"proj","alias":"p"}`), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Aliases) != 1 || p.Aliases[0] != "p" {
+		t.Fatalf("expected string alias to be decoded: %+v", p.Aliases)
+	}
+
+	if err := json.Unmarshal([]byte(`{"name":"proj","alias":["p","pr"]}`), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Aliases) != 2 || p.Aliases[1] != "pr" {
+		t.Fatalf("expected list of aliases to be decoded: %+v", p.Aliases)
+	}
+
+	b, _ := json.Marshal(Project{Name: "proj", Aliases: Aliases{"p"}})
+	if string(b) != `{"name":"proj","alias":"p"}` {
+		t.Fatalf("expected single alias to be encoded as string, got %s", b)
+	}
+}
+
+func TestProjectsLookupAmbiguousAlias(t *testing.T) {
+	projects := Projects{
+		{Name: "api", Aliases: Aliases{"backend"}},
+		{Name: "worker", Aliases: Aliases{"backend", "w"}},
+	}
+
+	if p, _, err := projects.Lookup("w"); err != nil || p.Name != "worker" {
+		t.Fatalf("expected to find project by alias, got %v %v", p, err)
+	}
+
+	_, _, err := projects.Lookup("backend")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+
+	if _, _, err := projects.Find("backend", ""); !errors.As(err, &ambiguous) {
+		t.Fatalf("expected Find to report ambiguity, got %v", err)
+	}
+}
+
+func TestProjectsCheckConflicts(t *testing.T) {
+	projects := Projects{
+		{Name: "api", Aliases: Aliases{"backend"}},
+	}
+
+	if err := projects.CheckConflicts(&Project{Name: "backend"}, -1); err == nil {
+		t.Fatalf("expected conflict with existing alias")
+	}
+	if err := projects.CheckConflicts(&Project{Name: "web", Aliases: Aliases{"api"}}, -1); err == nil {
+		t.Fatalf("expected conflict with existing name")
+	}
+	if err := projects.CheckConflicts(&Project{Name: "api", Aliases: Aliases{"backend"}}, 0); err != nil {
+		t.Fatalf("expected project to not conflict with itself: %v", err)
+	}
+	if err := projects.CheckConflicts(&Project{Name: "web", Aliases: Aliases{"ls"}}, -1); err == nil {
+		t.Fatalf("expected session subcommand names to be reserved")
+	}
+}
+
+func TestProjectsResolveLongestPrefix(t *testing.T) {
+	projects := Projects{
+		{Name: "src", RootPath: "/home/user/src", ProjectType: ProjectTypeLocal},
+		{Name: "api", RootPath: "/home/user/src/api", ProjectType: ProjectTypeLocal},
+		{Name: "remote", RootPath: "vscode-remote://ssh-remote+host/home/user/src/api/internal", ProjectType: ProjectTypeSSH},
+	}
+
+	if p, _ := projects.Resolve("/home/user/src/api/internal/db"); p == nil || p.Name != "api" {
+		t.Fatalf("expected nested path to resolve to api, got %v", p)
+	}
+	if p, _ := projects.Resolve("/home/user/src/apiv2"); p == nil || p.Name != "src" {
+		t.Fatalf("expected sibling with common prefix to resolve to src, got %v", p)
+	}
+	if p, _ := projects.Resolve("/home/other"); p != nil {
+		t.Fatalf("expected no project, got %v", p)
+	}
+}
+
+func TestProjectsFindPrefersPathOverSegmentName(t *testing.T) {
+	projects := Projects{
+		{Name: "api", RootPath: "/src/api", ProjectType: ProjectTypeLocal},
+		{Name: "db", RootPath: "/srv/db", ProjectType: ProjectTypeLocal, Aliases: []string{"internal"}},
+		{Name: "cache", RootPath: "/srv/cache", ProjectType: ProjectTypeLocal, Aliases: []string{"internal"}},
+	}
+	if p, _, err := projects.Find("db", "/src/api/internal/db"); err != nil || p.Name != "api" {
+		t.Fatalf("expected the project containing the path, got %v %v", p, err)
+	}
+	if p, _, err := projects.Find("internal", "/src/api/internal"); err != nil || p.Name != "api" {
+		t.Fatalf("expected a shared alias not to make the path ambiguous, got %v %v", p, err)
+	}
+}
+
+func TestProjectsResolveWorkspaceFolders(t *testing.T) {
+	dir := t.TempDir()
+	wsPath := filepath.Join(dir, "ws", "team.code-workspace")
+	if err := os.MkdirAll(filepath.Dir(wsPath), 0o755); err != nil {
+		t.Fatalf("failed to create workspace dir: %v", err)
+	}
+	content := `{"folders": [{"path": "../service"}]}`
+	if err := os.WriteFile(wsPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write workspace: %v", err)
+	}
+
+	projects := Projects{
+		{Name: "team", RootPath: wsPath, ProjectType: ProjectTypeLocal, IsWorkspace: true},
+	}
+
+	if p, _ := projects.Resolve(filepath.Join(dir, "service", "cmd")); p == nil || p.Name != "team" {
+		t.Fatalf("expected workspace folder member to resolve to workspace, got %v", p)
+	}
+	if p, _, err := projects.Find("cmd", filepath.Join(dir, "service", "cmd")); err != nil || p.Name != "team" {
+		t.Fatalf("expected Find to resolve from pwd, got %v %v", p, err)
+	}
+}
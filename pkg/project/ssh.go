@@ -0,0 +1,136 @@
+package project
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/filipenos/projects/pkg/sshconfig"
+)
+
+// loadSSHConfig is replaced in tests.
+var loadSSHConfig = sshconfig.LoadDefault
+
+// SSH transports supported for remote projects.
+const (
+	TransportSSH     = "ssh"
+	TransportMosh    = "mosh"
+	TransportAutoSSH = "autossh"
+)
+
+// SSHOptions tune how SSH projects are reached.
+type SSHOptions struct {
+	// Transport overrides the global ssh_transport setting
+	Transport    string   `json:"transport,omitempty"`
+	IdentityFile string   `json:"identityFile,omitempty"`
+	JumpHost     string   `json:"jumpHost,omitempty"`
+	Options      []string `json:"options,omitempty"`
+}
+
+// Args returns the ssh flags for the options, it is safe on a nil receiver.
+func (o *SSHOptions) Args() []string {
+	if o == nil {
+		return nil
+	}
+	var args []string
+	if o.IdentityFile != "" {
+		args = append(args, "-i", expandHome(o.IdentityFile))
+	}
+	if o.JumpHost != "" {
+		args = append(args, "-J", o.JumpHost)
+	}
+	for _, opt := range o.Options {
+		args = append(args, "-o", opt)
+	}
+	return args
+}
+
+// SSHTransport returns the transport of the project, falling back to the
+// global setting and then to plain ssh.
+func (p *Project) SSHTransport(global string) (string, error) {
+	transport := global
+	if p.SSH != nil && p.SSH.Transport != "" {
+		transport = p.SSH.Transport
+	}
+	switch transport {
+	case "":
+		return TransportSSH, nil
+	case TransportSSH, TransportMosh, TransportAutoSSH:
+		return transport, nil
+	}
+	return "", fmt.Errorf("unknown ssh transport %q, use ssh, mosh or autossh", transport)
+}
+
+// ResolveSSH returns the effective ssh config of the project host, a user
+// in the project uri wins over the config.
+func (p *Project) ResolveSSH() (sshconfig.Resolved, error) {
+	host, _, err := p.SSHInfo()
+	if err != nil {
+		return sshconfig.Resolved{}, err
+	}
+	c, err := loadSSHConfig()
+	if err != nil {
+		return sshconfig.Resolved{}, err
+	}
+	user, alias := splitUser(host)
+	r := c.Resolve(alias)
+	if user != "" {
+		r.User = user
+	}
+	return r, nil
+}
+
+// SSHHost returns the host alias of the project, without the user.
+func (p *Project) SSHHost() (string, error) {
+	host, _, err := p.SSHInfo()
+	if err != nil {
+		return "", err
+	}
+	_, alias := splitUser(host)
+	return alias, nil
+}
+
+// CheckSSHHost checks the host is defined in the ssh config. Host names and
+// addresses that ssh reaches without config, and the hex encoded authorities
+// VS Code writes, are accepted as they are. Short names from /etc/hosts are
+// not detected, so callers should report a failure rather than refuse the
+// project.
+func (p *Project) CheckSSHHost() error {
+	host, err := p.SSHHost()
+	if err != nil {
+		return err
+	}
+	if isDirectHost(host) || isEncodedHost(host) {
+		return nil
+	}
+	c, err := loadSSHConfig()
+	if err != nil {
+		return err
+	}
+	if !c.Known(host) {
+		return fmt.Errorf("ssh host '%s' of project '%s' not found in %s", host, p.Name, sshconfig.DefaultPath())
+	}
+	return nil
+}
+
+func splitUser(host string) (string, string) {
+	if i := strings.LastIndex(host, "@"); i != -1 {
+		return host[:i], host[i+1:]
+	}
+	return "", host
+}
+
+func isDirectHost(host string) bool {
+	return host == "localhost" || strings.Contains(host, ".") || net.ParseIP(host) != nil
+}
+
+// isEncodedHost reports hosts like "7b22686f73744e616d65223a...", the hex
+// encoded JSON VS Code uses when a host needs more than a name.
+func isEncodedHost(host string) bool {
+	if !strings.HasPrefix(host, "7b") {
+		return false
+	}
+	_, err := hex.DecodeString(host)
+	return err == nil
+}
@@ -0,0 +1,98 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/filipenos/projects/pkg/sshconfig"
+)
+
+func TestSSHOptionsArgs(t *testing.T) {
+	var empty *SSHOptions
+	if args := empty.Args(); args != nil {
+		t.Fatalf("expected no args, got %v", args)
+	}
+
+	t.Setenv("HOME", "/home/user")
+	opts := &SSHOptions{
+		IdentityFile: "~/.ssh/work",
+		JumpHost:     "bastion",
+		Options:      []string{"ServerAliveInterval=30", "Port=2222"},
+	}
+	expected := "-i /home/user/.ssh/work -J bastion -o ServerAliveInterval=30 -o Port=2222"
+	if got := strings.Join(opts.Args(), " "); got != expected {
+		t.Fatalf("unexpected args: %s", got)
+	}
+}
+
+func TestSSHTransport(t *testing.T) {
+	p := &Project{}
+	if transport, _ := p.SSHTransport(""); transport != TransportSSH {
+		t.Fatalf("expected ssh by default, got %s", transport)
+	}
+	if transport, _ := p.SSHTransport("autossh"); transport != TransportAutoSSH {
+		t.Fatalf("expected global transport, got %s", transport)
+	}
+
+	p.SSH = &SSHOptions{Transport: "mosh"}
+	if transport, _ := p.SSHTransport("autossh"); transport != TransportMosh {
+		t.Fatalf("expected project transport, got %s", transport)
+	}
+
+	p.SSH.Transport = "telnet"
+	if _, err := p.SSHTransport(""); err == nil {
+		t.Fatal("expected error for unknown transport")
+	}
+}
+
+func withSSHConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	original := loadSSHConfig
+	t.Cleanup(func() { loadSSHConfig = original })
+	loadSSHConfig = func() (*sshconfig.Config, error) {
+		return sshconfig.Load(path)
+	}
+}
+
+func TestCheckSSHHost(t *testing.T) {
+	withSSHConfig(t, "Host devbox\n  HostName 10.0.0.5\n  User alice\n")
+
+	tests := map[string]bool{
+		"vscode-remote://ssh-remote+devbox/srv/api":                                 true,
+		"vscode-remote://ssh-remote+bob@devbox/srv/api":                             true,
+		"vscode-remote://ssh-remote+build.example.com/srv/api":                      true,
+		"vscode-remote://ssh-remote+10.0.0.7/srv/api":                               true,
+		"vscode-remote://ssh-remote+7b22686f73744e616d65223a226465762d766d227d/srv": true,
+		"vscode-remote://ssh-remote+missing/srv/api":                                false,
+	}
+	for root, valid := range tests {
+		p := &Project{Name: "api", RootPath: root}
+		p.Init()
+		if err := p.Validate(); err != nil {
+			t.Errorf("Validate(%s) = %v, the ssh config must not be required", root, err)
+		}
+		if err := p.CheckSSHHost(); (err == nil) != valid {
+			t.Errorf("CheckSSHHost(%s) = %v, want valid=%v", root, err, valid)
+		}
+	}
+}
+
+func TestResolveSSH(t *testing.T) {
+	withSSHConfig(t, "Host devbox\n  HostName 10.0.0.5\n  User alice\n  Port 2222\n")
+
+	p := &Project{Name: "api", RootPath: "vscode-remote://ssh-remote+bob@devbox/srv/api"}
+	p.Init()
+	r, err := p.ResolveSSH()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.HostName != "10.0.0.5" || r.User != "bob" || r.Port != "2222" {
+		t.Fatalf("unexpected resolution: %+v", r)
+	}
+}
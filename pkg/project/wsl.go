@@ -0,0 +1,28 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WSLInfo returns the distro and the directory inside it of a WSL project.
+func (p *Project) WSLInfo() (distro string, dir string, err error) {
+	if p.ProjectType != ProjectTypeWSL {
+		return "", "", fmt.Errorf("project is not WSL type")
+	}
+	return p.remoteInfo()
+}
+
+// WSLLocalPath maps a WSL project to a local path when running inside its
+// distro, currentDistro being the WSL_DISTRO_NAME of this environment.
+// Distro names are case insensitive, like wsl.exe treats them.
+func (p *Project) WSLLocalPath(currentDistro string) (string, bool) {
+	if currentDistro == "" || p.ProjectType != ProjectTypeWSL {
+		return "", false
+	}
+	distro, _, err := p.remoteInfo()
+	if err != nil || !strings.EqualFold(distro, currentDistro) {
+		return "", false
+	}
+	return p.Path, true
+}
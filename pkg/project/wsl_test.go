@@ -0,0 +1,45 @@
+package project
+
+import "testing"
+
+func TestWSLLocalPath(t *testing.T) {
+	p := &Project{Name: "api", RootPath: "vscode-remote://wsl+Ubuntu-22.04/home/user/api"}
+	p.Init()
+
+	distro, dir, err := p.WSLInfo()
+	if err != nil || distro != "Ubuntu-22.04" || dir != "/home/user/api" {
+		t.Fatalf("unexpected info: %s %s %v", distro, dir, err)
+	}
+
+	tests := []struct {
+		current  string
+		expected string
+		local    bool
+	}{
+		{"Ubuntu-22.04", "/home/user/api", true},
+		{"ubuntu-22.04", "/home/user/api", true},
+		{"Debian", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, local := p.WSLLocalPath(tt.current)
+		if got != tt.expected || local != tt.local {
+			t.Errorf("WSLLocalPath(%q) = %q, %v; want %q, %v", tt.current, got, local, tt.expected, tt.local)
+		}
+	}
+
+	ws := &Project{Name: "ws", RootPath: "vscode-remote://wsl+Ubuntu/home/user/ws.code-workspace"}
+	ws.Init()
+	if _, dir, _ := ws.WSLInfo(); dir != "/home/user" {
+		t.Fatalf("expected workspace parent dir, got %s", dir)
+	}
+	if got, _ := ws.WSLLocalPath("Ubuntu"); got != "/home/user/ws.code-workspace" {
+		t.Fatalf("expected workspace file, got %s", got)
+	}
+
+	local := &Project{Name: "local", RootPath: "/src/local"}
+	local.Init()
+	if _, ok := local.WSLLocalPath("Ubuntu"); ok {
+		t.Fatal("local projects are not WSL projects")
+	}
+}
@@ -0,0 +1,260 @@
+// Package sshconfig reads the OpenSSH client configuration, enough to know
+// which hosts are defined and how they resolve.
+package sshconfig
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// maxIncludeDepth matches the limit used by ssh.
+const maxIncludeDepth = 16
+
+// Host is a Host block with its patterns and options. Option names are lower
+// case and only the first value of each option is kept, like ssh does.
+type Host struct {
+	Patterns []string
+	Options  map[string]string
+}
+
+// Config is the list of Host blocks in the order ssh evaluates them.
+type Config struct {
+	Hosts []Host
+}
+
+// Resolved is the effective configuration of a host alias.
+type Resolved struct {
+	Alias        string
+	HostName     string
+	User         string
+	Port         string
+	IdentityFile string
+	ProxyJump    string
+	ProxyCommand string
+}
+
+// DefaultPath returns the path of the user ssh config.
+func DefaultPath() string {
+	return filepath.Join(os.Getenv("HOME"), ".ssh", "config")
+}
+
+// LoadDefault loads the user ssh config.
+func LoadDefault() (*Config, error) {
+	return Load(DefaultPath())
+}
+
+// Load parses the ssh config at path following Include directives. A missing
+// file is an empty config.
+func Load(path string) (*Config, error) {
+	c := &Config{}
+	// options before the first Host line apply to every host
+	c.Hosts = append(c.Hosts, Host{Patterns: []string{"*"}, Options: map[string]string{}})
+	if err := c.parseFile(path, filepath.Dir(path), 0); err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Config) parseFile(path, baseDir string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("ssh config: too many nested includes in %s", path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value := parseLine(scanner.Text())
+		if key == "" {
+			continue
+		}
+		switch key {
+		case "host":
+			c.Hosts = append(c.Hosts, Host{Patterns: strings.Fields(value), Options: map[string]string{}})
+		case "match":
+			// Match conditions are not evaluated, their options never apply
+			c.Hosts = append(c.Hosts, Host{Options: map[string]string{}})
+		case "include":
+			for _, pattern := range strings.Fields(value) {
+				if err := c.include(pattern, baseDir, depth); err != nil {
+					return err
+				}
+			}
+		default:
+			options := c.Hosts[len(c.Hosts)-1].Options
+			if _, ok := options[key]; !ok {
+				options[key] = value
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (c *Config) include(pattern, baseDir string, depth int) error {
+	pattern = expandHome(pattern)
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(baseDir, pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("ssh config: invalid include %q: %w", pattern, err)
+	}
+	for _, match := range matches {
+		if err := c.parseFile(match, baseDir, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseLine splits "Key value" and "Key=value" lines, dropping comments and
+// quotes around the value.
+func parseLine(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+	i := strings.IndexAny(line, " \t=")
+	if i == -1 {
+		return strings.ToLower(line), ""
+	}
+	key := strings.ToLower(line[:i])
+	value := strings.TrimLeft(line[i:], " \t")
+	value = strings.TrimPrefix(value, "=")
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	return key, value
+}
+
+// Aliases returns the hosts declared without wildcards, in config order.
+func (c *Config) Aliases() []string {
+	var aliases []string
+	seen := map[string]bool{}
+	for _, h := range c.Hosts {
+		for _, pattern := range h.Patterns {
+			if strings.ContainsAny(pattern, "*?!") || seen[pattern] {
+				continue
+			}
+			seen[pattern] = true
+			aliases = append(aliases, pattern)
+		}
+	}
+	return aliases
+}
+
+// Known reports whether a Host block other than the catch-all "*" matches
+// the alias.
+func (c *Config) Known(alias string) bool {
+	for _, h := range c.Hosts {
+		if h.matches(alias) && !isCatchAll(h.Patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve applies the matching Host blocks to alias, first value wins.
+func (c *Config) Resolve(alias string) Resolved {
+	options := map[string]string{}
+	for _, h := range c.Hosts {
+		if !h.matches(alias) {
+			continue
+		}
+		for key, value := range h.Options {
+			if _, ok := options[key]; !ok {
+				options[key] = value
+			}
+		}
+	}
+
+	r := Resolved{
+		Alias:        alias,
+		HostName:     strings.ReplaceAll(options["hostname"], "%h", alias),
+		User:         options["user"],
+		Port:         options["port"],
+		IdentityFile: options["identityfile"],
+		ProxyJump:    options["proxyjump"],
+		ProxyCommand: options["proxycommand"],
+	}
+	if r.HostName == "" {
+		r.HostName = alias
+	}
+	if r.User == "" {
+		if u, err := user.Current(); err == nil {
+			r.User = u.Username
+		}
+	}
+	if r.Port == "" {
+		r.Port = "22"
+	}
+	return r
+}
+
+func (h Host) matches(alias string) bool {
+	matched := false
+	for _, pattern := range h.Patterns {
+		if negated := strings.HasPrefix(pattern, "!"); negated {
+			if match(pattern[1:], alias) {
+				return false
+			}
+			continue
+		}
+		if match(pattern, alias) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+func isCatchAll(patterns []string) bool {
+	return len(patterns) == 1 && patterns[0] == "*"
+}
+
+// match implements the ssh pattern syntax, where * matches any sequence and
+// ? a single character.
+func match(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = pattern[1:]
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if match(pattern, s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if s == "" {
+				return false
+			}
+		default:
+			if s == "" || !strings.EqualFold(pattern[:1], s[:1]) {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return s == ""
+}
+
+func expandHome(name string) string {
+	if strings.HasPrefix(name, "~/") {
+		return filepath.Join(os.Getenv("HOME"), name[2:])
+	}
+	return name
+}
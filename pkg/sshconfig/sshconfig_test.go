@@ -0,0 +1,128 @@
+package sshconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config"), `
+# global options
+ServerAliveInterval 30
+Include conf.d/*
+
+Host devbox dev
+    HostName 10.0.0.5
+    User alice
+    Port=2222
+
+Host *.corp !legacy.corp
+    User corp
+    ProxyJump bastion
+
+Match host foo
+    User ignored
+
+Host *
+    User fallback
+    IdentityFile "~/.ssh/id_ed25519"
+`)
+	writeFile(t, filepath.Join(dir, "conf.d", "work"), `
+Host build
+    HostName %h.internal
+`)
+
+	c, err := Load(filepath.Join(dir, "config"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if got := strings.Join(c.Aliases(), ","); got != "build,devbox,dev" {
+		t.Fatalf("unexpected aliases: %s", got)
+	}
+
+	r := c.Resolve("dev")
+	if r.HostName != "10.0.0.5" || r.User != "alice" || r.Port != "2222" {
+		t.Fatalf("unexpected resolution: %+v", r)
+	}
+	if r.IdentityFile != "~/.ssh/id_ed25519" {
+		t.Fatalf("expected identity from catch-all, got %q", r.IdentityFile)
+	}
+
+	r = c.Resolve("build")
+	if r.HostName != "build.internal" || r.User != "fallback" || r.Port != "22" {
+		t.Fatalf("unexpected resolution: %+v", r)
+	}
+
+	r = c.Resolve("git.corp")
+	if r.User != "corp" || r.ProxyJump != "bastion" || r.HostName != "git.corp" {
+		t.Fatalf("unexpected resolution: %+v", r)
+	}
+	if r := c.Resolve("legacy.corp"); r.User != "fallback" {
+		t.Fatalf("expected negated pattern to be skipped, got %+v", r)
+	}
+
+	for alias, known := range map[string]bool{
+		"devbox":      true,
+		"build":       true,
+		"git.corp":    true,
+		"legacy.corp": false,
+		"foo":         false,
+		"unknown":     false,
+	} {
+		if c.Known(alias) != known {
+			t.Errorf("Known(%q) = %v, want %v", alias, !known, known)
+		}
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "config"))
+	if err != nil {
+		t.Fatalf("expected missing config to be empty, got %v", err)
+	}
+	if len(c.Aliases()) != 0 || c.Known("devbox") {
+		t.Fatal("expected no hosts")
+	}
+}
+
+func TestIncludeLoop(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config"), "Include config\n")
+	if _, err := Load(filepath.Join(dir, "config")); err == nil {
+		t.Fatal("expected error for recursive include")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		expected   bool
+	}{
+		{"*", "anything", true},
+		{"dev?", "dev1", true},
+		{"dev?", "dev", false},
+		{"*.corp", "git.corp", true},
+		{"*.corp", "git.corp.com", false},
+		{"DEV", "dev", true},
+		{"a*b*c", "axxbyyc", true},
+	}
+	for _, tt := range tests {
+		if got := match(tt.pattern, tt.s); got != tt.expected {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package task
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Task is a named command that can be run inside a project.
+type Task struct {
+	Name        string            `json:"-"`
+	Command     string            `json:"command"`
+	Dir         string            `json:"dir,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Source      string            `json:"-"`
+}
+
+// SourceProject identifies tasks declared in the projects file.
+const SourceProject = "project"
+
+type discoverer struct {
+	files []string
+	parse func(dir, file string) ([]Task, error)
+}
+
+var discoverers = []discoverer{
+	{files: []string{"Makefile", "makefile", "GNUmakefile"}, parse: parseMakefile},
+	{files: []string{"package.json"}, parse: parsePackageJSON},
+	{files: []string{"Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml"}, parse: parseTaskfile},
+	{files: []string{"justfile", "Justfile", ".justfile"}, parse: parseJustfile},
+}
+
+// Discover looks for task runners in dir and returns their tasks. When two
+// runners declare the same name the first one found wins, in the order
+// Makefile, package.json, Taskfile and justfile.
+func Discover(dir string) ([]Task, error) {
+	var tasks []Task
+	seen := map[string]bool{}
+	for _, d := range discoverers {
+		for _, name := range d.files {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				continue
+			}
+			found, err := d.parse(dir, name)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range found {
+				if seen[t.Name] {
+					continue
+				}
+				seen[t.Name] = true
+				tasks = append(tasks, t)
+			}
+			break
+		}
+	}
+	return tasks, nil
+}
+
+// Merge combines the tasks declared on the project with the discovered ones,
+// declared tasks taking precedence. The result is sorted by name.
+func Merge(declared map[string]Task, discovered []Task) []Task {
+	tasks := make([]Task, 0, len(declared)+len(discovered))
+	for name, t := range declared {
+		t.Name = name
+		t.Source = SourceProject
+		tasks = append(tasks, t)
+	}
+	for _, t := range discovered {
+		if _, ok := declared[t.Name]; ok {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks
+}
+
+// Find returns the task called name.
+func Find(tasks []Task, name string) (Task, bool) {
+	for _, t := range tasks {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
+
+var makeTargetRe = regexp.MustCompile(`^([A-Za-z0-9_][A-Za-z0-9_./-]*)\s*:([^=]|$)`)
+
+func parseMakefile(dir, file string) ([]Task, error) {
+	var tasks []Task
+	err := scanLines(filepath.Join(dir, file), func(line string) {
+		m := makeTargetRe.FindStringSubmatch(line)
+		if m == nil || strings.Contains(m[1], "%") || strings.Contains(line, ":=") {
+			return
+		}
+		tasks = append(tasks, Task{Name: m[1], Command: "make " + m[1], Source: file})
+	})
+	return tasks, err
+}
+
+func parsePackageJSON(dir, file string) ([]Task, error) {
+	b, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(b, &pkg); err != nil {
+		return nil, err
+	}
+
+	runner := "npm run"
+	switch {
+	case exists(filepath.Join(dir, "pnpm-lock.yaml")):
+		runner = "pnpm run"
+	case exists(filepath.Join(dir, "yarn.lock")):
+		runner = "yarn run"
+	case exists(filepath.Join(dir, "bun.lockb")), exists(filepath.Join(dir, "bun.lock")):
+		runner = "bun run"
+	}
+
+	var tasks []Task
+	for name, script := range pkg.Scripts {
+		tasks = append(tasks, Task{Name: name, Command: runner + " " + name, Description: script, Source: file})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Name < tasks[j].Name })
+	return tasks, nil
+}
+
+// parseTaskfile reads the keys of the top level "tasks:" mapping. It avoids a
+// YAML dependency by relying on the indentation of the first task.
+func parseTaskfile(dir, file string) ([]Task, error) {
+	var (
+		tasks   []Task
+		inTasks bool
+		indent  = -1
+	)
+	err := scanLines(filepath.Join(dir, file), func(line string) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			return
+		}
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if lineIndent == 0 {
+			inTasks = trimmed == "tasks:"
+			return
+		}
+		if !inTasks {
+			return
+		}
+		if indent == -1 {
+			indent = lineIndent
+		}
+		if lineIndent != indent || !strings.HasSuffix(strings.SplitN(trimmed, " #", 2)[0], ":") {
+			return
+		}
+		name := strings.Trim(strings.TrimSuffix(strings.SplitN(trimmed, " #", 2)[0], ":"), `"'`)
+		tasks = append(tasks, Task{Name: name, Command: "task " + name, Source: file})
+	})
+	return tasks, err
+}
+
+var justRecipeRe = regexp.MustCompile(`^@?([A-Za-z0-9_][A-Za-z0-9_-]*)[^:]*:([^=]|$)`)
+
+func parseJustfile(dir, file string) ([]Task, error) {
+	var tasks []Task
+	err := scanLines(filepath.Join(dir, file), func(line string) {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' {
+			return
+		}
+		for _, keyword := range []string{"set ", "alias ", "export ", "import ", "mod "} {
+			if strings.HasPrefix(line, keyword) {
+				return
+			}
+		}
+		m := justRecipeRe.FindStringSubmatch(line)
+		if m == nil {
+			return
+		}
+		tasks = append(tasks, Task{Name: m[1], Command: "just " + m[1], Source: file})
+	})
+	return tasks, err
+}
+
+func scanLines(name string, fn func(line string)) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fn(strings.TrimRight(scanner.Text(), " \t\r"))
+	}
+	return scanner.Err()
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func names(tasks []Task) map[string]Task {
+	m := map[string]Task{}
+	for _, t := range tasks {
+		m[t.Name] = t
+	}
+	return m
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Makefile", `.PHONY: test build
+VERSION := 1.0
+CFLAGS ::= -O2
+
+build: deps
+	go build ./...
+
+test:
+	go test ./...
+
+%.o: %.c
+	cc -c $<
+`)
+	writeFile(t, dir, "package.json", `{"scripts": {"lint": "eslint .", "test": "jest"}}`)
+	writeFile(t, dir, "yarn.lock", "")
+	writeFile(t, dir, "Taskfile.yml", `version: '3'
+
+vars:
+  NAME: api
+
+tasks:
+  deploy:
+    desc: Deploy
+    cmds:
+      - echo deploy
+  "docs:serve":
+    cmds:
+      - mkdocs serve
+`)
+	writeFile(t, dir, "justfile", `set shell := ["bash", "-c"]
+name := "api"
+alias r := release
+
+# release the project
+release version='1.0': build
+    echo {{version}}
+
+@fmt:
+    gofmt -w .
+`)
+
+	tasks, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	found := names(tasks)
+
+	expected := map[string]string{
+		"build":      "make build",
+		"test":       "make test",
+		"lint":       "yarn run lint",
+		"deploy":     "task deploy",
+		"docs:serve": "task docs:serve",
+		"release":    "just release",
+		"fmt":        "just fmt",
+	}
+	if len(found) != len(expected) {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+	for name, command := range expected {
+		if found[name].Command != command {
+			t.Fatalf("task %s: expected command %q, got %q", name, command, found[name].Command)
+		}
+	}
+	if found["test"].Source != "Makefile" {
+		t.Fatalf("expected Makefile to win on duplicated names, got %s", found["test"].Source)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	declared := map[string]Task{
+		"test": {Command: "go test -race ./...", Dir: "backend"},
+	}
+	discovered := []Task{
+		{Name: "test", Command: "make test", Source: "Makefile"},
+		{Name: "build", Command: "make build", Source: "Makefile"},
+	}
+
+	tasks := Merge(declared, discovered)
+	if len(tasks) != 2 || tasks[0].Name != "build" || tasks[1].Name != "test" {
+		t.Fatalf("unexpected merge result: %+v", tasks)
+	}
+	if tasks[1].Source != SourceProject || tasks[1].Dir != "backend" {
+		t.Fatalf("expected declared task to win: %+v", tasks[1])
+	}
+
+	if _, ok := Find(tasks, "missing"); ok {
+		t.Fatalf("expected missing task to not be found")
+	}
+}
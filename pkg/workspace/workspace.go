@@ -3,6 +3,7 @@ package workspace
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"os"
 	"strings"
@@ -21,18 +22,36 @@ type RemoteSSHDefaultForwardedPorts struct {
 	LocalPort  int    `json:"localPort"`
 	RemotePort int    `json:"remotePort"`
 }
+
+// Local returns the local port, the remote one when it is not set.
+func (f RemoteSSHDefaultForwardedPorts) Local() int {
+	if f.LocalPort == 0 {
+		return f.RemotePort
+	}
+	return f.LocalPort
+}
+
+// LocalForward returns the ssh -L spec of the port.
+func (f RemoteSSHDefaultForwardedPorts) LocalForward() string {
+	return fmt.Sprintf("%d:localhost:%d", f.Local(), f.RemotePort)
+}
+
 type Settings struct {
 	RemoteSSHDefaultForwardedPorts []RemoteSSHDefaultForwardedPorts `json:"remote.SSH.defaultForwardedPorts"`
 }
 
 func Load(path string) (*Workspace, error) {
-	w := new(Workspace)
 	file, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	file = stripJSONC(file)
-	if err = json.Unmarshal(file, w); err != nil {
+	return Parse(path, file)
+}
+
+// Parse decodes workspace content read elsewhere, like from a remote host.
+func Parse(path string, data []byte) (*Workspace, error) {
+	w := new(Workspace)
+	if err := json.Unmarshal(stripJSONC(data), w); err != nil {
 		return nil, err
 	}
 	w.Path = path
@@ -62,3 +62,28 @@ func TestWorkspacePaths(t *testing.T) {
 		t.Fatalf("FoldersPath returned unexpected result: %v", paths)
 	}
 }
+
+func TestForwardedPorts(t *testing.T) {
+	ws, err := Parse("/remote/proj.code-workspace", []byte(`{
+		// comments are allowed
+		"settings": {
+			"remote.SSH.defaultForwardedPorts": [
+				{"name": "web", "localPort": 8080, "remotePort": 3000},
+				{"remotePort": 5432},
+			]
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	ports := ws.Settings.RemoteSSHDefaultForwardedPorts
+	if len(ports) != 2 {
+		t.Fatalf("expected 2 ports, got %d", len(ports))
+	}
+	if got := ports[0].LocalForward(); got != "8080:localhost:3000" {
+		t.Fatalf("unexpected forward: %s", got)
+	}
+	if got := ports[1].LocalForward(); got != "5432:localhost:5432" {
+		t.Fatalf("expected remote port to be used locally, got %s", got)
+	}
+}